@@ -0,0 +1,79 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// BatchError collects the per-key failures of a batch operation.
+// Keys are symbols, regions or whatever the batch was keyed by; successful keys are absent.
+type BatchError struct {
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	messages := make([]string, 0, len(keys))
+	for _, key := range keys {
+		messages = append(messages, fmt.Sprintf("%s: %v", key, e.Errors[key]))
+	}
+	return fmt.Sprintf("%d request(s) failed: %s", len(keys), strings.Join(messages, "; "))
+}
+
+// Unwrap exposes the individual errors to errors.Is and errors.As
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// forEachConcurrent calls fn for every unique key with at most limit calls in flight.
+// It returns a *BatchError holding the keys whose call failed, or nil when all succeeded.
+func forEachConcurrent(keys []string, limit int, fn func(key string) error) error {
+	if limit <= 0 {
+		limit = DefaultConcurrency
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		errs      = make(map[string]error)
+		seen      = make(map[string]bool, len(keys))
+		semaphore = make(chan struct{}, limit)
+	)
+
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(key string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			if err := fn(key); err != nil {
+				mu.Lock()
+				errs[key] = err
+				mu.Unlock()
+			}
+		}(key)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return &BatchError{Errors: errs}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package yfinance_api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache keeps successful responses in memory for ttl, keyed by endpoint and query parameters
+type responseCache struct {
+	mu        sync.Mutex
+	ttl       time.Duration
+	entries   map[string]cacheEntry
+	lastSweep time.Time
+}
+
+// cacheEntry is a buffered response along with its expiry
+type cacheEntry struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+	expires    time.Time
+}
+
+// bufferResponse reads and closes the body of resp, keeping it in an entry that can be replayed
+func bufferResponse(resp *http.Response) (cacheEntry, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return cacheEntry{}, err
+	}
+	return cacheEntry{statusCode: resp.StatusCode, header: resp.Header, body: body}, nil
+}
+
+// response returns a fresh response over the buffered body, so that every caller can consume its own copy
+func (e cacheEntry) response() *http.Response {
+	return &http.Response{
+		StatusCode:    e.statusCode,
+		Status:        http.StatusText(e.statusCode),
+		Header:        e.header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+	}
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{ttl: ttl, entries: make(map[string]cacheEntry), lastSweep: now()}
+}
+
+// cacheKey identifies a request by its endpoint and parameters, which never include the crumb
+func cacheKey(endpoint string, params url.Values) string {
+	return endpoint + "?" + params.Encode()
+}
+
+// get returns a fresh copy of the cached response for key, if any and not expired
+func (rc *responseCache) get(key string) (*http.Response, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !now().Before(entry.expires) {
+		delete(rc.entries, key)
+		return nil, false
+	}
+
+	return entry.response(), true
+}
+
+// store keeps a buffered response in the cache under key for the cache's ttl
+func (rc *responseCache) store(key string, entry cacheEntry) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	current := now()
+	// Drop expired entries from time to time so that one-off keys don't accumulate
+	if current.Sub(rc.lastSweep) >= rc.ttl {
+		for entryKey, entry := range rc.entries {
+			if !current.Before(entry.expires) {
+				delete(rc.entries, entryKey)
+			}
+		}
+		rc.lastSweep = current
+	}
+
+	entry.header = entry.header.Clone()
+	entry.expires = current.Add(rc.ttl)
+	rc.entries[key] = entry
+}
+
+// invalidate removes the entries of requests about symbol, or every entry when symbol is empty
+func (rc *responseCache) invalidate(symbol string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key := range rc.entries {
+		if symbol == "" || cacheKeyMentions(key, symbol) {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// cacheKeyMentions reports whether the request behind key is about symbol, either as the last path segment
+// (e.g. /v8/finance/chart/AAPL) or as one of the comma-separated values of a query parameter
+func cacheKeyMentions(key, symbol string) bool {
+	parsed, err := url.Parse(key)
+	if err != nil {
+		return false
+	}
+
+	if segment := parsed.Path[strings.LastIndex(parsed.Path, "/")+1:]; strings.EqualFold(segment, symbol) {
+		return true
+	}
+	for _, values := range parsed.Query() {
+		for _, value := range values {
+			for _, item := range strings.Split(value, ",") {
+				if strings.EqualFold(item, symbol) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestWithCache tests that repeated requests are served from the cache until the TTL expires or the symbol is invalidated
+func TestWithCache(t *testing.T) {
+	frozen := time.Date(2024, 3, 15, 14, 0, 0, 0, time.UTC)
+	setNow(t, frozen)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, "response %d", requests.Load())
+	}))
+	defer server.Close()
+
+	client := newTestClient(WithCache(time.Minute))
+	get := func(path string, params url.Values) string {
+		t.Helper()
+		resp, err := client.Get(server.URL+path, params)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return string(body)
+	}
+
+	chart := url.Values{"range": {"1d"}}
+	if first, second := get("/v8/finance/chart/AAPL", chart), get("/v8/finance/chart/AAPL", chart); first != "response 1" || second != first {
+		t.Errorf("Expected the second request to be served from the cache, got %q then %q", first, second)
+	}
+	if body := get("/v8/finance/chart/AAPL", url.Values{"range": {"5d"}}); body != "response 2" {
+		t.Errorf("Expected other parameters to miss the cache, got %q", body)
+	}
+	get("/v7/finance/quote", url.Values{"symbols": {"MSFT,AAPL"}})
+
+	// Errors are never cached
+	for i := 0; i < 2; i++ {
+		if _, err := client.Get(server.URL+"/missing", url.Values{}); err == nil {
+			t.Fatal("Expected an error for a 404")
+		}
+	}
+	if got := requests.Load(); got != 5 {
+		t.Fatalf("Expected 5 requests so far, got %d", got)
+	}
+
+	client.InvalidateCache("aapl")
+	get("/v8/finance/chart/AAPL", chart)
+	get("/v7/finance/quote", url.Values{"symbols": {"MSFT,AAPL"}})
+	if got := requests.Load(); got != 7 {
+		t.Errorf("Expected both AAPL entries to be invalidated, got %d requests", got)
+	}
+
+	setNow(t, frozen.Add(2*time.Minute))
+	get("/v8/finance/chart/AAPL", url.Values{"range": {"5d"}})
+	if got := requests.Load(); got != 8 {
+		t.Errorf("Expected the expired entry to be fetched again, got %d requests", got)
+	}
+}
+
+// TestCacheKeyMentions tests matching cache keys to symbols
+func TestCacheKeyMentions(t *testing.T) {
+	testCases := []struct {
+		key      string
+		expected bool
+	}{
+		{key: "https://query2.finance.yahoo.com/v10/finance/quoteSummary/AAPL?modules=price", expected: true},
+		{key: "https://query2.finance.yahoo.com/v7/finance/quote?symbols=MSFT%2CAAPL", expected: true},
+		{key: "https://query2.finance.yahoo.com/v10/finance/quoteSummary/AAP?modules=price", expected: false},
+		{key: "https://query2.finance.yahoo.com/v1/finance/search?q=Apple", expected: false},
+	}
+
+	for _, tc := range testCases {
+		if got := cacheKeyMentions(tc.key, "AAPL"); got != tc.expected {
+			t.Errorf("cacheKeyMentions(%q) = %v, expected %v", tc.key, got, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package yfinance_api
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Trading sessions of a candle, as set in Candle.Session
+const (
+	SessionPre     = "pre"
+	SessionRegular = "regular"
+	SessionPost    = "post"
+)
+
+// Candle is one bar of a price series, in chronological order unlike the date-keyed map of FetchHistoricalData
+type Candle struct {
+	Time     time.Time `json:"time"` // Start of the bar in the exchange timezone
+	Open     *float64  `json:"open"`
+	High     *float64  `json:"high"`
+	Low      *float64  `json:"low"`
+	Close    *float64  `json:"close"`
+	Volume   *int64    `json:"volume"`
+	AdjClose *float64  `json:"adjClose,omitempty"` // Close adjusted for splits and dividends, only given for daily bars and above
+	Session  string    `json:"session"`            // SessionPre, SessionRegular or SessionPost; always SessionRegular for daily bars and above
+}
+
+// HaltWindow is a run of candles that suggests trading was halted or the feed went stale
+type HaltWindow struct {
+	Start   time.Time `json:"start"`   // Time of the first halted candle
+	End     time.Time `json:"end"`     // Time of the last halted candle
+	Candles int       `json:"candles"` // Number of consecutive halted candles
+}
+
+// defaultHaltMinCandles is the number of consecutive empty or zero-volume candles DetectHalts requires
+// to report a halt when not given one
+const defaultHaltMinCandles = 5
+
+// FetchCandles retrieves the price series of the ticker as chronologically ordered candles.
+// It takes the same range and interval values as FetchHistoricalData, with the same defaults.
+func (t *Ticker) FetchCandles(rangeParam, interval string) ([]Candle, error) {
+	return t.FetchHistoricalDataSeries(rangeParam, interval, "", "")
+}
+
+// FetchIntradayCandles retrieves an intraday price series such as ("5d", "5m") as chronologically ordered candles,
+// each tagged with its trading session. Empty range and interval get the defaults of FetchHistoricalData.
+// Pre-market and after-hours candles are requested from Yahoo and kept when includePrePost is true,
+// and filtered out otherwise.
+func (t *Ticker) FetchIntradayCandles(rangeParam, interval string, includePrePost bool) ([]Candle, error) {
+	params := historyParams(rangeParam, interval, "", "")
+	params.Add("includePrePost", strconv.FormatBool(includePrePost))
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return nil, err
+	}
+
+	series := candles(historyResponse)
+	if includePrePost {
+		return series, nil
+	}
+
+	// Yahoo may still return a few extended-hours bars around the session boundaries
+	regular := series[:0]
+	for _, candle := range series {
+		if candle.Session == SessionRegular {
+			regular = append(regular, candle)
+		}
+	}
+	return regular, nil
+}
+
+// FetchHistoricalDataSeries retrieves the same data as FetchHistoricalData, with the same parameters and
+// defaults, as chronologically ordered candles instead of a date-keyed map. Bars Yahoo has no prices for,
+// such as halted intraday periods, are kept with nil prices and volume rather than being dropped.
+func (t *Ticker) FetchHistoricalDataSeries(rangeParam, interval, period1, period2 string) ([]Candle, error) {
+	historyResponse, err := t.fetchChart(historyParams(rangeParam, interval, period1, period2))
+	if err != nil {
+		return nil, err
+	}
+
+	return candles(historyResponse), nil
+}
+
+// FetchHistoryWithEvents retrieves the price series of the ticker as candles sorted by time, along with
+// the dividends and stock splits over the same range, oldest first. This is what is needed to rebuild
+// a total-return series or to detect splits. Prices are as reported by Yahoo, not adjusted for the events.
+func (t *Ticker) FetchHistoryWithEvents(rangeParam, interval string) (HistoryWithEvents, error) {
+	params := historyParams(rangeParam, interval, "", "")
+	params.Add("events", "div,splits")
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return HistoryWithEvents{}, err
+	}
+
+	meta := historyResponse.Chart.Result[0].Meta
+	location := exchangeLocation(meta.ExchangeTimezoneName, meta.Gmtoffset)
+
+	return HistoryWithEvents{
+		Candles:   candles(historyResponse),
+		Dividends: dividendEvents(historyResponse, location),
+		Splits:    splitEvents(historyResponse, location),
+	}, nil
+}
+
+// DetectHalts returns the windows of at least minCandles consecutive candles without a close
+// or without volume, which in intraday data usually means a trading halt or a stale feed.
+// minCandles defaults to 5 when not positive. Thinly traded instruments can have zero-volume bars
+// during normal trading, so the threshold may need raising for them.
+func DetectHalts(series []Candle, minCandles int) []HaltWindow {
+	if minCandles <= 0 {
+		minCandles = defaultHaltMinCandles
+	}
+
+	var halts []HaltWindow
+	start := -1
+
+	flush := func(end int) {
+		if start >= 0 && end-start >= minCandles {
+			halts = append(halts, HaltWindow{Start: series[start].Time, End: series[end-1].Time, Candles: end - start})
+		}
+		start = -1
+	}
+
+	for i, candle := range series {
+		halted := candle.Close == nil || candle.Volume == nil || *candle.Volume == 0
+		if halted && start < 0 {
+			start = i
+		}
+		if !halted {
+			flush(i)
+		}
+	}
+	flush(len(series))
+
+	return halts
+}
+
+// candles converts a chart response into candles sorted by ascending time in the exchange timezone
+func candles(data YahooHistoryResponse) []Candle {
+	if len(data.Chart.Result) == 0 {
+		return nil
+	}
+
+	series := make([]Candle, 0, len(data.Chart.Result[0].Timestamp))
+	_ = eachCandle(data, func(candle Candle) error {
+		series = append(series, candle)
+		return nil
+	})
+	// Yahoo sends the bars in order, but nothing in the response guarantees it
+	sort.SliceStable(series, func(i, j int) bool { return series[i].Time.Before(series[j].Time) })
+	return series
+}
+
+// eachCandle calls fn with the candles of a chart response one at a time, in the exchange timezone,
+// stopping at the first error fn returns
+func eachCandle(data YahooHistoryResponse, fn func(Candle) error) error {
+	if len(data.Chart.Result) == 0 {
+		return nil
+	}
+
+	result := data.Chart.Result[0]
+	location := exchangeLocation(result.Meta.ExchangeTimezoneName, result.Meta.Gmtoffset)
+	regular := result.Meta.CurrentTradingPeriod.Regular
+	session := sessionClassifier(regular.Start, regular.End, result.Meta.DataGranularity, location)
+	for i, timestamp := range result.Timestamp {
+		candle := Candle{Time: time.Unix(timestamp, 0).In(location)}
+		candle.Session = session(candle.Time)
+		if len(result.Indicators.Quote) > 0 {
+			quote := result.Indicators.Quote[0]
+			candle.Open = pointAt(quote.Open, i)
+			candle.High = pointAt(quote.High, i)
+			candle.Low = pointAt(quote.Low, i)
+			candle.Close = pointAt(quote.Close, i)
+			candle.Volume = pointAt(quote.Volume, i)
+		}
+		if len(result.Indicators.Adjclose) > 0 {
+			candle.AdjClose = pointAt(result.Indicators.Adjclose[0].Adjclose, i)
+		}
+		if err := fn(candle); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sessionClassifier returns a function telling the trading session of a bar from its time of day, using the
+// boundaries of the current regular session as reported in the chart meta. Those are only given for the
+// current day, so they are applied to every day in the exchange timezone. Bars of daily or longer granularity,
+// or without boundaries, are always regular.
+func sessionClassifier(regularStart, regularEnd int64, granularity string, location *time.Location) func(time.Time) string {
+	intraday := strings.HasSuffix(granularity, "m") || strings.HasSuffix(granularity, "h")
+	if !intraday || regularStart == 0 || regularEnd <= regularStart {
+		return func(time.Time) string { return SessionRegular }
+	}
+
+	minuteOfDay := func(t time.Time) int { return t.Hour()*60 + t.Minute() }
+	open := minuteOfDay(time.Unix(regularStart, 0).In(location))
+	closing := minuteOfDay(time.Unix(regularEnd, 0).In(location))
+
+	return func(t time.Time) string {
+		switch minute := minuteOfDay(t); {
+		case minute < open:
+			return SessionPre
+		case minute >= closing:
+			return SessionPost
+		default:
+			return SessionRegular
+		}
+	}
+}
+
+// pointAt returns values[i], or nil when the series is shorter than the timestamps
+func pointAt[T any](values []*T, i int) *T {
+	if i < len(values) {
+		return values[i]
+	}
+	return nil
+}
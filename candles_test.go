@@ -0,0 +1,189 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestDetectHalts tests reporting runs of empty or zero-volume candles above the default and a given threshold
+func TestDetectHalts(t *testing.T) {
+	base := time.Date(2024, 3, 15, 9, 30, 0, 0, time.UTC)
+	var series []Candle
+	add := func(n int, close *float64, volume *int64) {
+		for i := 0; i < n; i++ {
+			series = append(series, Candle{Time: base.Add(time.Duration(len(series)) * time.Minute), Close: close, Volume: volume})
+		}
+	}
+
+	add(3, floatPtr(10), int64Ptr(100))
+	add(6, nil, nil) // halt of 6 candles starting at 09:33
+	add(2, floatPtr(10), int64Ptr(100))
+	add(3, floatPtr(10), int64Ptr(0)) // too short to be a halt
+	add(1, floatPtr(10), int64Ptr(100))
+	add(5, floatPtr(10), int64Ptr(0)) // zero-volume halt running to the end of the series
+
+	halts := DetectHalts(series, 0)
+	if len(halts) != 2 {
+		t.Fatalf("Expected 2 halts, got %+v", halts)
+	}
+
+	if halts[0].Candles != 6 || halts[0].Start.Format("15:04") != "09:33" || halts[0].End.Format("15:04") != "09:38" {
+		t.Errorf("Unexpected first halt: %+v", halts[0])
+	}
+	if halts[1].Candles != 5 || halts[1].End != series[len(series)-1].Time {
+		t.Errorf("Unexpected second halt: %+v", halts[1])
+	}
+
+	// A lower threshold also reports the shorter zero-volume run
+	if halts := DetectHalts(series, 3); len(halts) != 3 || halts[1].Candles != 3 {
+		t.Errorf("Expected 3 halts with a threshold of 3, got %+v", halts)
+	}
+}
+
+// TestFetchCandles tests converting the chart response into ordered candles
+func TestFetchCandles(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-14400},
+		"timestamp":[1710509400,1710509460],
+		"indicators":{"quote":[{"open":[1,2],"high":[1,2],"low":[1,2],"close":[1,null],"volume":[100]}]}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	series, err := ticker.FetchCandles("1d", "1m")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(series) != 2 {
+		t.Fatalf("Expected 2 candles, got %d", len(series))
+	}
+	if series[0].Time.Format("2006-01-02 15:04") != "2024-03-15 09:30" || *series[0].Close != 1 || *series[0].Volume != 100 {
+		t.Errorf("Unexpected first candle: %+v", series[0])
+	}
+	if series[1].Close != nil || series[1].Volume != nil {
+		t.Errorf("Expected missing close and volume on the second candle, got %+v", series[1])
+	}
+}
+
+// TestFetchHistoricalDataSeries tests that the series is sorted by time and keeps bars without prices
+func TestFetchHistoricalDataSeries(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-14400},
+		"timestamp":[1710768600,1710509400,1710855000],
+		"indicators":{"quote":[{"open":[2,1,null],"high":[2,1,null],"low":[2,1,null],"close":[2,1,null],"volume":[200,100,null]}]}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	series, err := ticker.FetchHistoricalDataSeries("", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(series) != 3 {
+		t.Fatalf("Expected 3 candles, got %d", len(series))
+	}
+	for i := 1; i < len(series); i++ {
+		if !series[i-1].Time.Before(series[i].Time) {
+			t.Errorf("Expected ascending times, got %v before %v", series[i-1].Time, series[i].Time)
+		}
+	}
+	if series[0].Close == nil || *series[0].Close != 1 {
+		t.Errorf("Expected the earliest candle first, got %+v", series[0])
+	}
+	if last := series[2]; last.Close != nil || last.Volume != nil {
+		t.Errorf("Expected a candle without prices to be kept with nil values, got %+v", last)
+	}
+}
+
+// TestFetchHistoryWithEvents tests parsing the dividends and splits returned alongside the candles
+func TestFetchHistoryWithEvents(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-14400},
+		"timestamp":[1598880600,1596807000],
+		"indicators":{"quote":[{"open":[127.6,114.9],"high":[131,115],"low":[126,113],"close":[129.04,111.11],"volume":[225702700,198045600]}]},
+		"events":{
+			"dividends":{"1596807000":{"amount":0.82,"date":1596807000}},
+			"splits":{"1598880600":{"date":1598880600,"numerator":4,"denominator":1,"splitRatio":"4:1"}}}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	history, err := ticker.FetchHistoryWithEvents("1y", "1d")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(history.Candles) != 2 || !history.Candles[0].Time.Before(history.Candles[1].Time) {
+		t.Errorf("Expected 2 candles sorted by time, got %+v", history.Candles)
+	}
+	if len(history.Dividends) != 1 || history.Dividends[0].Amount != 0.82 {
+		t.Errorf("Unexpected dividends: %+v", history.Dividends)
+	}
+	if len(history.Splits) != 1 {
+		t.Fatalf("Expected 1 split, got %+v", history.Splits)
+	}
+	split := history.Splits[0]
+	if split.Numerator != 4 || split.Denominator != 1 || split.Date.Format("2006-01-02") != "2020-08-31" {
+		t.Errorf("Unexpected split: %+v", split)
+	}
+	if split.Date.Location().String() != "America/New_York" {
+		t.Errorf("Expected the split dated in the exchange timezone, got %s", split.Date.Location())
+	}
+}
+
+// TestFetchIntradayCandles tests tagging candles with their session and filtering extended hours
+func TestFetchIntradayCandles(t *testing.T) {
+	requests := 0
+	var includePrePost, ranges, intervals []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		includePrePost = append(includePrePost, r.URL.Query().Get("includePrePost"))
+		ranges = append(ranges, r.URL.Query().Get("range"))
+		intervals = append(intervals, r.URL.Query().Get("interval"))
+		fmt.Fprint(w, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-14400,
+			"dataGranularity":"1h","currentTradingPeriod":{"regular":{"start":1710509400,"end":1710532800}}},
+			"timestamp":[1710417600,1710504000,1710511200,1710536400],
+			"indicators":{"quote":[{"open":[1,2,3,4],"high":[1,2,3,4],"low":[1,2,3,4],"close":[1,2,3,4],"volume":[1,2,3,4]}]}}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	series, err := ticker.FetchIntradayCandles("5d", "1h", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{SessionPre, SessionPre, SessionRegular, SessionPost}
+	if len(series) != len(expected) {
+		t.Fatalf("Expected %d candles, got %d", len(expected), len(series))
+	}
+	for i, session := range expected {
+		if series[i].Session != session {
+			t.Errorf("Expected candle at %s to be %s, got %q", series[i].Time.Format("01-02 15:04"), session, series[i].Session)
+		}
+	}
+
+	series, err = ticker.FetchIntradayCandles("5d", "1h", false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(series) != 1 || series[0].Time.Format("15:04") != "10:00" {
+		t.Errorf("Expected only the regular session candle, got %+v", series)
+	}
+	if includePrePost[0] != "true" || includePrePost[1] != "false" {
+		t.Errorf("Unexpected includePrePost parameters %v", includePrePost)
+	}
+
+	// Empty values get the same defaults as the other history methods
+	if _, err := ticker.FetchIntradayCandles("", "", true); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ranges[2] != "1y" || intervals[2] != "1d" {
+		t.Errorf("Expected range=1y and interval=1d by default, got %q and %q", ranges[2], intervals[2])
+	}
+}
+
+// TestSessionClassifierDaily tests that daily bars are always in the regular session
+func TestSessionClassifierDaily(t *testing.T) {
+	session := sessionClassifier(1710509400, 1710532800, "1d", time.UTC)
+	if got := session(time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)); got != SessionRegular {
+		t.Errorf("Expected a daily bar to be regular, got %s", got)
+	}
+}
@@ -1,116 +1,721 @@
 package yfinance_api
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"math/big"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
 type YFinanceAPI struct {
 	Client *Client
 }
 
+// Doer sends HTTP requests. *http.Client implements it; tests can provide a fake that serves canned responses.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
 type Client struct {
-	client  *http.Client
-	cookies []*http.Cookie
-	crumb   string
+	client      Doer
+	authMu      sync.RWMutex // guards cookies and crumb
+	bootstrapMu sync.Mutex   // serializes fetching cookies and crumb so concurrent first calls fetch them once
+	cookies     []*http.Cookie
+	crumb       string
+	retryPolicy RetryPolicy
+	maxAttempts int
+	retryDelay  time.Duration // base delay of the exponential backoff
+	bootstrap   time.Duration // bounds fetching the cookies and crumb, zero for no bound of its own
+	headers     http.Header
+	limiter     *rateLimiter   // paces every HTTP attempt, nil when unlimited
+	cache       *responseCache // nil unless enabled with WithCache
+	flights     flightGroup    // coalesces concurrent identical requests
+	baseUrl     string         // overrides BaseUrl when set
+	cookieUrl   string         // overrides CookieUrl when set
+	crumbUrl    string         // overrides the getcrumb endpoint of the base URL when set
+	locations   sync.Map       // symbol -> *time.Location of its exchange
+	fxRates     sync.Map       // "EURUSD" -> fxRateEntry, reused for FXRateTTL
+	tradingDays sync.Map       // symbol -> tradingCalendar derived from its daily history
+	logger      *slog.Logger   // receives the internal logs, nil discards them
+	optionErr   error          // set by an option given an invalid value, returned by every request
+	userAgent   string         // sent with every request, so that the cookie, crumb and data requests match
+}
+
+// Option configures a Client created with NewClientWithOptions
+type Option func(*Client)
+
+// RetryPolicy decides whether a request should be retried after an attempt.
+// It receives the response (nil when err is set), the transport error and the 1-based attempt number,
+// and returns whether to retry along with the delay to wait before the next attempt.
+// Returning a zero delay lets the client apply its own exponential backoff with jitter.
+type RetryPolicy func(resp *http.Response, err error, attempt int) (retry bool, delay time.Duration)
+
+// WithTimeout bounds every request made by the client, including retries' individual attempts,
+// replacing DefaultTimeout. Zero disables the timeout. It has no effect on a Doer set with WithDoer
+// that isn't an *http.Client.
+// Per-call deadlines can be tightened further with a context, see Client.GetWithContext and Ticker.WithContext.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		httpClient, ok := c.client.(*http.Client)
+		if !ok {
+			return
+		}
+		// Copy so that a client passed to WithHTTPClient is never modified
+		client := *httpClient
+		client.Timeout = timeout
+		c.client = &client
+	}
+}
+
+// WithBootstrapTimeout bounds fetching the session cookies and crumb, replacing DefaultBootstrapTimeout.
+// Zero leaves only the client timeout and the request context to bound it.
+func WithBootstrapTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.bootstrap = timeout
+	}
+}
+
+// WithHTTPClient makes the client send its requests through hc, e.g. to set a proxy, a custom transport
+// or instrumentation. The timeout of hc is kept as is, so a zero timeout means none; combine with
+// WithTimeout, placed after this option, to set one without modifying hc.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.client = hc
+	}
+}
+
+// WithProxy sends the requests of the client, including the cookie and crumb bootstrap, through the
+// proxy at proxyURL, e.g. "http://proxy.corp:3128" or "socks5://127.0.0.1:1080". An empty proxyURL
+// restores the default, which reads the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+// It applies to the *http.Client of the client, so place it after WithHTTPClient; it has no effect
+// on a Doer set with WithDoer that isn't an *http.Client. An invalid proxyURL makes every request fail
+// with an error matching ErrInvalidParameter.
+func WithProxy(proxyURL string) Option {
+	return func(c *Client) {
+		httpClient, ok := c.client.(*http.Client)
+		if !ok {
+			return
+		}
+
+		proxy := http.ProxyFromEnvironment
+		if proxyURL != "" {
+			parsed, err := url.Parse(proxyURL)
+			if err != nil || parsed.Host == "" {
+				c.optionErr = fmt.Errorf("invalid proxy URL %q: %w", proxyURL, ErrInvalidParameter)
+				return
+			}
+			switch parsed.Scheme {
+			case "http", "https", "socks5", "socks5h":
+			default:
+				c.optionErr = fmt.Errorf("unsupported proxy scheme %q, expected http, https or socks5: %w", parsed.Scheme, ErrInvalidParameter)
+				return
+			}
+			proxy = http.ProxyURL(parsed)
+		}
+
+		// Copy the client and its transport so that a client passed to WithHTTPClient is never modified
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if current, ok := httpClient.Transport.(*http.Transport); ok {
+			transport = current.Clone()
+		}
+		transport.Proxy = proxy
+		client := *httpClient
+		client.Transport = transport
+		c.client = &client
+	}
+}
+
+// WithDoer makes the client send its requests through d, typically a fake serving canned responses
+// so that the Fetch methods can be tested without network access
+func WithDoer(d Doer) Option {
+	return func(c *Client) {
+		c.client = d
+	}
+}
+
+// WithDefaultHeaders adds the given headers to every request made by the client.
+// A User-Agent set here replaces the one of the client, see WithUserAgent.
+func WithDefaultHeaders(headers http.Header) Option {
+	return func(c *Client) {
+		c.headers = headers.Clone()
+	}
+}
+
+// WithUserAgent sends userAgent with every request of the client instead of the one picked from UserAgents.
+// Either way, the client keeps a single User-Agent for its lifetime, since Yahoo may reject a crumb used
+// under another User-Agent than the one it was obtained with.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithLogger sends the internal logs of the client, such as failed requests and retries, to logger.
+// By default they are discarded, as every failure is also returned as an error.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// WithSilentLogging discards the internal logs of the client, undoing an earlier WithLogger
+func WithSilentLogging() Option {
+	return func(c *Client) {
+		c.logger = nil
+	}
+}
+
+// WithRateLimit paces the requests of the client to requestsPerSecond on average, allowing bursts of up to
+// burst requests, replacing DefaultRateLimit and DefaultRateBurst. Every HTTP attempt, including retries and
+// the crumb bootstrap, waits for its turn while respecting the request context.
+// A requestsPerSecond of zero or less disables the limit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(requestsPerSecond, burst)
+	}
+}
+
+// WithCache keeps successful responses in memory for ttl, so that repeated requests for the same endpoint
+// and parameters within ttl are answered without reaching Yahoo. Caching is off by default, as cached
+// prices can be up to ttl old; use Client.InvalidateCache to drop the entries of a symbol.
+func WithCache(ttl time.Duration) Option {
+	return func(c *Client) {
+		if ttl <= 0 {
+			c.cache = nil
+			return
+		}
+		c.cache = newResponseCache(ttl)
+	}
+}
+
+// WithBaseURL sends the requests of the client to baseURL instead of BaseUrl, e.g. a caching proxy
+// or an httptest.Server. A trailing slash is ignored.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseUrl = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithCrumbURL fetches the crumb from crumbURL instead of the /v1/test/getcrumb endpoint of the base URL
+func WithCrumbURL(crumbURL string) Option {
+	return func(c *Client) {
+		c.crumbUrl = crumbURL
+	}
+}
+
+// WithCookieURL fetches the session cookies from cookieURL instead of CookieUrl
+func WithCookieURL(cookieURL string) Option {
+	return func(c *Client) {
+		c.cookieUrl = cookieURL
+	}
+}
+
+// WithRetry sets how many attempts a request gets, including the first one, and the initial backoff delay,
+// which doubles after every failed attempt up to DefaultRetryMaxDelay. A maxAttempts of 1 disables retries.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts = maxAttempts
+		c.retryDelay = baseDelay
+	}
+}
+
+// WithRetryPolicy replaces the default retry policy with a custom one.
+// The policy is consulted after every attempt until it declines or the max attempts are reached.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// RetryClassifier reports whether a response or transport error is worth retrying.
+// A classifier that inspects the body must leave resp.Body readable for the caller, e.g. by replacing it
+// with a new reader over the bytes it consumed.
+type RetryClassifier func(resp *http.Response, err error) bool
+
+// WithRetryClassifier replaces the default decision of what is retryable while keeping the client's
+// attempt cap, Retry-After handling and exponential backoff.
+func WithRetryClassifier(classify RetryClassifier) Option {
+	return WithRetryPolicy(func(resp *http.Response, err error, attempt int) (bool, time.Duration) {
+		if !classify(resp, err) {
+			return false, 0
+		}
+		if resp != nil {
+			return true, retryAfter(resp)
+		}
+		return true, 0
+	})
+}
+
+// DefaultRetryPolicy retries transport errors, 429 and 5xx gateway responses.
+// A Retry-After header is honored when present, up to DefaultRetryMaxDelay; otherwise the client's backoff is used.
+// Unknown hosts and cancelled requests are never retried since another attempt cannot succeed.
+func DefaultRetryPolicy(resp *http.Response, err error, attempt int) (bool, time.Duration) {
+	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+			return false, 0
+		}
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false, 0
+		}
+		return true, 0
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true, retryAfter(resp)
+	}
+	return false, 0
 }
 
 var instance *Client
 var once sync.Once
 
+// randomUserAgent picks one of UserAgents with crypto/rand, falling back to the first one if it fails
+func randomUserAgent() string {
+	randomIndex, err := rand.Int(rand.Reader, big.NewInt(int64(len(UserAgents))))
+	if err != nil {
+		return UserAgents[0]
+	}
+	return UserAgents[randomIndex.Int64()]
+}
+
+func newClient() *Client {
+	return &Client{
+		client:      &http.Client{Timeout: DefaultTimeout},
+		userAgent:   randomUserAgent(),
+		cookies:     []*http.Cookie{},
+		crumb:       "",
+		retryPolicy: DefaultRetryPolicy,
+		maxAttempts: DefaultMaxAttempts,
+		retryDelay:  DefaultRetryBaseDelay,
+		bootstrap:   DefaultBootstrapTimeout,
+		limiter:     newRateLimiter(DefaultRateLimit, DefaultRateBurst),
+	}
+}
+
 func getClient() *Client {
 	once.Do(func() {
-		instance = &Client{client: &http.Client{}, cookies: []*http.Cookie{}, crumb: ""}
+		instance = newClient()
 	})
 	return instance
 }
 
+// Get requests url with the given query parameters, adding the crumb and cookies Yahoo requires.
+// Transient failures are retried according to the retry policy; a final non-2xx response is returned as an *APIError.
+// When caching is enabled with WithCache, a fresh cached response is returned without any request.
+// Concurrent calls for the same url and parameters share a single round trip, each receiving its own copy
+// of the response. A call that isn't shared and isn't cached streams the body from the connection.
 func (c *Client) Get(url string, params url.Values) (*http.Response, error) {
-	c.getCrumb()
-	return c.get(url, params)
+	return c.GetWithContext(context.Background(), url, params)
 }
 
-func (c *Client) get(url string, params url.Values) (*http.Response, error) {
-	if c.crumb != "" {
-		params.Add("crumb", c.crumb)
+// GetWithContext is like Get but bound to ctx, which can cancel the call or give it a deadline.
+// The deadline composes with the client timeout set by WithTimeout: whichever expires first ends the call.
+// When the round trip is shared with other calls, it goes on for them and is only abandoned, retries
+// included, once every caller's context is done.
+func (c *Client) GetWithContext(ctx context.Context, url string, params url.Values) (*http.Response, error) {
+	if c.optionErr != nil {
+		return nil, c.optionErr
+	}
+
+	key := cacheKey(url, params)
+	if c.cache != nil {
+		if resp, ok := c.cache.get(key); ok {
+			return resp, nil
+		}
+	}
+
+	var store func(cacheEntry)
+	if c.cache != nil {
+		store = func(entry cacheEntry) { c.cache.store(key, entry) }
 	}
-	url = fmt.Sprintf("%s?%s", url, params.Encode())
-	req, err := http.NewRequest("GET", url, nil)
+	return c.flights.do(ctx, key, c.cache != nil, func(ctx context.Context) (*http.Response, error) {
+		return c.getFresh(ctx, url, params)
+	}, store)
+}
+
+// getFresh requests url from Yahoo, refreshing the crumb once if it is rejected
+func (c *Client) getFresh(ctx context.Context, url string, params url.Values) (*http.Response, error) {
+	c.getCrumb(ctx)
+	crumb := c.currentCrumb()
+	resp, err := c.get(ctx, url, params)
 	if err != nil {
-		slog.Error("Failed to create request", "err", err)
 		return nil, err
 	}
 
-	for _, cookie := range c.cookies {
-		req.AddCookie(cookie)
+	// Yahoo rejects expired crumbs: fetch new cookies and crumb, then try once more. The refresh is capped
+	// to a single one per call so that a crumb Yahoo keeps rejecting can't loop forever.
+	if invalidCrumb(resp) {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		c.log().Warn("Refreshing crumb after rejected request", "status", resp.StatusCode)
+		c.refreshCrumb(ctx, crumb)
+		resp, err = c.get(ctx, url, params)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// Use crypto/rand for secure random number generation
-	randomIndex, err := rand.Int(rand.Reader, big.NewInt(int64(len(UserAgents))))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		apiErr := newAPIError(resp)
+		c.log().Error("Unexpected status from Yahoo Finance API", "status", apiErr.StatusCode)
+		return nil, apiErr
+	}
+
+	return resp, nil
+}
+
+// InvalidateCache drops the cached responses of requests about symbol, or every cached response
+// when symbol is empty, so that the next request reaches Yahoo. It does nothing without WithCache.
+func (c *Client) InvalidateCache(symbol string) {
+	if c.cache != nil {
+		c.cache.invalidate(symbol)
+	}
+}
+
+func (c *Client) get(ctx context.Context, endpoint string, params url.Values) (*http.Response, error) {
+	c.authMu.RLock()
+	crumb, cookies := c.crumb, c.cookies
+	c.authMu.RUnlock()
+
+	// Copy the parameters so that the caller's are left untouched and a repeated request gets a single crumb
+	query := make(url.Values, len(params)+1)
+	for key, values := range params {
+		query[key] = append([]string(nil), values...)
+	}
+	if crumb != "" {
+		query.Set("crumb", crumb)
+	}
+	requestURL := fmt.Sprintf("%s?%s", endpoint, query.Encode())
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(ctx, requestURL, cookies)
+		if c.retryPolicy == nil || attempt >= c.maxAttempts {
+			return resp, err
+		}
+
+		retry, delay := c.retryPolicy(resp, err, attempt)
+		if !retry {
+			return resp, err
+		}
+
+		// Release the connection before trying again
+		if resp != nil {
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		if delay <= 0 {
+			delay = backoff(c.retryDelay, attempt)
+		}
+		c.log().Warn("Retrying request to Yahoo Finance API", "attempt", attempt, "delay", delay)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) do(ctx context.Context, url string, cookies []*http.Cookie) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		slog.Error("Failed to generate secure random number", "err", err)
-		// Fallback to first user agent if random generation fails
-		req.Header.Set("User-Agent", UserAgents[0])
-	} else {
-		req.Header.Set("User-Agent", UserAgents[randomIndex.Int64()])
+		c.log().Error("Failed to create request", "err", err)
+		return nil, err
+	}
+
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+
+	for key, values := range c.headers {
+		req.Header[key] = append([]string(nil), values...)
+	}
+
+	if req.Header.Get("User-Agent") == "" {
+		userAgent := c.userAgent
+		if userAgent == "" {
+			userAgent = UserAgents[0]
+		}
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
 	}
 
 	resp, err := c.client.Do(req)
 	if err != nil {
-		slog.Error("Failed to get data from Yahoo Finance API", "err", err)
+		c.log().Error("Failed to get data from Yahoo Finance API", "err", err)
+		return nil, err
+	}
+
+	if err := decodeContentEncoding(resp); err != nil {
+		_ = resp.Body.Close()
+		c.log().Error("Failed to decompress response", "err", err)
 		return nil, err
 	}
 
 	return resp, nil
 }
 
-func (c *Client) getCookie() {
-	if len(c.cookies) > 0 {
+// decodeContentEncoding transparently decompresses gzip bodies. net/http only does so when it negotiated
+// the compression itself, not when Accept-Encoding was set explicitly, e.g. through WithDefaultHeaders.
+func decodeContentEncoding(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read gzip response body: %w", err)
+	}
+
+	resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// gzipBody reads the decompressed stream and closes both it and the underlying body
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	err := b.Reader.Close()
+	if closeErr := b.body.Close(); closeErr != nil {
+		return closeErr
+	}
+	return err
+}
+
+// backoff returns the exponential delay from base for the given attempt with up to 50% random jitter
+func backoff(base time.Duration, attempt int) time.Duration {
+	delay := base << (attempt - 1)
+	if delay <= 0 || delay > DefaultRetryMaxDelay {
+		delay = DefaultRetryMaxDelay
+	}
+
+	jitter, err := rand.Int(rand.Reader, big.NewInt(int64(delay/2)+1))
+	if err != nil {
+		return delay
+	}
+	return delay + time.Duration(jitter.Int64())
+}
+
+// retryAfter parses the Retry-After header, which may be given in seconds or as an HTTP date, capped at
+// DefaultRetryMaxDelay so that a server cannot stall an uncancellable request. It returns zero when the
+// header is absent or invalid.
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		if seconds > int(DefaultRetryMaxDelay/time.Second) {
+			return DefaultRetryMaxDelay
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return min(time.Until(date), DefaultRetryMaxDelay)
+	}
+	return 0
+}
+
+// getCookie fetches the session cookies Yahoo requires. Callers must hold bootstrapMu.
+func (c *Client) getCookie(ctx context.Context) {
+	c.authMu.RLock()
+	hasCookies := len(c.cookies) > 0
+	c.authMu.RUnlock()
+	if hasCookies {
 		return
 	}
 
-	endpoint := "https://fc.yahoo.com"
-	resp, err := c.get(endpoint, url.Values{})
+	resp, err := c.get(ctx, c.cookieURL(), url.Values{})
 	if err != nil {
-		slog.Error("Failed to get cookie", "err", err)
+		c.log().Error("Failed to get cookie", "err", err)
 		return
 	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			c.log().Error("Error closing response body:", "err", err)
+		}
+	}(resp.Body)
 
+	c.authMu.Lock()
 	c.cookies = resp.Cookies()
+	c.authMu.Unlock()
+}
+
+// getCrumb lazily fetches the cookies and crumb. Concurrent callers wait for a single fetch.
+func (c *Client) getCrumb(ctx context.Context) {
+	// A misconfigured client must not bootstrap, e.g. directly instead of through an invalid proxy
+	if c.hasCrumb() || c.optionErr != nil {
+		return
+	}
+
+	c.bootstrapMu.Lock()
+	defer c.bootstrapMu.Unlock()
+
+	// Another goroutine may have fetched the crumb while we were waiting
+	if c.hasCrumb() {
+		return
+	}
+
+	c.fetchCrumb(ctx)
+}
+
+// invalidCrumb reports whether resp rejects the crumb or cookies. Yahoo answers 401 for an expired crumb,
+// while a 403 is only treated as such when its body mentions the crumb, as it is also used for blocked clients.
+// The body is buffered and restored so that it can still be read when the response isn't a crumb rejection.
+func invalidCrumb(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden:
+		body, err := io.ReadAll(io.LimitReader(resp.Body, apiErrorBodyLimit))
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return err == nil && strings.Contains(strings.ToLower(string(body)), "crumb")
+	default:
+		return false
+	}
 }
 
-func (c *Client) getCrumb() {
-	if c.crumb != "" {
+// refreshCrumb drops the cookies and crumb and fetches new ones, unless another goroutine already replaced
+// the stale crumb, so that a burst of rejected requests triggers a single refresh
+func (c *Client) refreshCrumb(ctx context.Context, stale string) {
+	c.bootstrapMu.Lock()
+	defer c.bootstrapMu.Unlock()
+
+	if c.currentCrumb() != stale {
 		return
 	}
 
-	c.getCookie()
-	endpoint := fmt.Sprintf("%s/v1/test/getcrumb", BaseUrl)
-	resp, err := c.get(endpoint, url.Values{})
+	c.authMu.Lock()
+	c.crumb = ""
+	c.cookies = nil
+	c.authMu.Unlock()
+
+	c.fetchCrumb(ctx)
+}
+
+// fetchCrumb requests the cookies, if missing, and a new crumb. Callers must hold bootstrapMu.
+// The crumb is left unset when Yahoo answers with an error status or an empty body, such as a rate limit page.
+func (c *Client) fetchCrumb(ctx context.Context) {
+	if c.bootstrap > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.bootstrap)
+		defer cancel()
+	}
+
+	c.getCookie(ctx)
+	resp, err := c.get(ctx, c.crumbURL(), url.Values{})
 	if err != nil {
-		slog.Error("Failed to get crumb", "err", err)
+		c.log().Error("Failed to get crumb", "err", err)
 		return
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Error closing response body:", "err", err)
+			c.log().Error("Error closing response body:", "err", err)
 		}
 	}(resp.Body)
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		slog.Error("Error reading response body:", "err", err)
+		c.log().Error("Error reading response body:", "err", err)
+		return
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		c.log().Error("Failed to get crumb", "status", resp.StatusCode)
+		return
+	}
+	if strings.TrimSpace(string(body)) == "" {
+		c.log().Error("Failed to get crumb", "err", "empty response body")
 		return
 	}
 
+	c.authMu.Lock()
 	c.crumb = string(body)
+	c.authMu.Unlock()
+}
+
+// baseURL returns the base URL of the Yahoo Finance API for the client
+func (c *Client) baseURL() string {
+	if c.baseUrl != "" {
+		return c.baseUrl
+	}
+	return BaseUrl
+}
+
+// crumbURL returns the URL the client fetches its crumb from
+func (c *Client) crumbURL() string {
+	if c.crumbUrl != "" {
+		return c.crumbUrl
+	}
+	return fmt.Sprintf("%s/v1/test/getcrumb", c.baseURL())
+}
+
+// cookieURL returns the URL the client fetches its session cookies from
+func (c *Client) cookieURL() string {
+	if c.cookieUrl != "" {
+		return c.cookieUrl
+	}
+	return CookieUrl
+}
+
+// discardLogger drops every record, for clients without a logger
+var discardLogger = slog.New(discardHandler{})
+
+// discardHandler is a slog.Handler that is never enabled
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// log returns the logger of the client, discarding the records when none was set with WithLogger
+func (c *Client) log() *slog.Logger {
+	if c.logger != nil {
+		return c.logger
+	}
+	return discardLogger
+}
+
+func (c *Client) hasCrumb() bool {
+	return c.currentCrumb() != ""
+}
+
+func (c *Client) currentCrumb() string {
+	c.authMu.RLock()
+	defer c.authMu.RUnlock()
+	return c.crumb
 }
 
 // NewClient creates and returns a new YFinance API client instance
@@ -121,9 +726,32 @@ func NewClient() *YFinanceAPI {
 	}
 }
 
+// NewClientWithOptions creates a YFinance API client with its own underlying Client configured by opts.
+// Unlike NewClient it does not share the package-wide singleton, so its cookies, crumb and settings are isolated.
+// The singleton returned by NewClient cannot be configured and always uses the defaults, such as DefaultTimeout.
+func NewClientWithOptions(opts ...Option) *YFinanceAPI {
+	client := newClient()
+	for _, opt := range opts {
+		opt(client)
+	}
+	return &YFinanceAPI{
+		Client: client,
+	}
+}
+
 // NewTicker creates a new ticker instance for the given symbol
-// This is a convenience function that creates a client and ticker in one call
-func NewTicker(symbol string) *Ticker {
-	client := NewClient()
-	return client.InstantiateTicker(symbol)
+// This is a convenience function that creates a client and ticker in one call.
+// Without options the ticker uses the shared singleton client, like NewClient, so every such ticker shares
+// its cookies, crumb and settings. Passing options gives the ticker its own isolated client instead.
+func NewTicker(symbol string, opts ...Option) *Ticker {
+	if len(opts) > 0 {
+		return NewClientWithOptions(opts...).InstantiateTicker(symbol)
+	}
+	return NewClient().InstantiateTicker(symbol)
+}
+
+// NewTickerWithClient creates a ticker for the given symbol bound to an explicit client,
+// e.g. one created with NewClientWithOptions and shared by a group of tickers
+func NewTickerWithClient(symbol string, c *YFinanceAPI) *Ticker {
+	return c.InstantiateTicker(symbol)
 }
@@ -0,0 +1,905 @@
+package yfinance_api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient creates a client with a preset crumb so requests skip the cookie/crumb bootstrap.
+// The rate limit is lifted unless opts set one, since test servers don't throttle.
+func newTestClient(opts ...Option) *Client {
+	client := NewClientWithOptions(append([]Option{WithRateLimit(0, 0)}, opts...)...).Client
+	client.crumb = "test-crumb"
+	client.cookies = []*http.Cookie{{Name: "B", Value: "test"}}
+	return client
+}
+
+// fakeDoer answers every request with a canned JSON body, recording the requests it receives
+type fakeDoer struct {
+	mu       sync.Mutex
+	body     string
+	status   int
+	requests []*http.Request
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	d.requests = append(d.requests, req)
+	d.mu.Unlock()
+
+	status := d.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(d.body)),
+		Request:    req,
+	}, nil
+}
+
+// TestNewClientWithOptions tests that option clients are isolated from the singleton
+func TestNewClientWithOptions(t *testing.T) {
+	client := NewClientWithOptions()
+	if client.Client == nil {
+		t.Fatal("NewClientWithOptions() returned YFinanceAPI with nil Client")
+	}
+
+	if client.Client == NewClient().Client {
+		t.Error("NewClientWithOptions() should not return the singleton Client")
+	}
+}
+
+// TestDefaultRetryPolicy tests which responses the default policy retries
+func TestDefaultRetryPolicy(t *testing.T) {
+	testCases := []struct {
+		name   string
+		status int
+		header string
+		err    error
+		retry  bool
+		delay  time.Duration
+	}{
+		{name: "OK", status: http.StatusOK, retry: false},
+		{name: "Not found", status: http.StatusNotFound, retry: false},
+		{name: "Rate limited", status: http.StatusTooManyRequests, retry: true},
+		{name: "Rate limited with Retry-After", status: http.StatusTooManyRequests, header: "2", retry: true, delay: 2 * time.Second},
+		{name: "Service unavailable", status: http.StatusServiceUnavailable, retry: true},
+		{name: "Network error", err: errors.New("connection reset"), retry: true},
+		{name: "Unknown host", err: &net.DNSError{Err: "no such host", IsNotFound: true}, retry: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var resp *http.Response
+			if tc.err == nil {
+				resp = &http.Response{StatusCode: tc.status, Header: http.Header{}}
+				if tc.header != "" {
+					resp.Header.Set("Retry-After", tc.header)
+				}
+			}
+
+			retry, delay := DefaultRetryPolicy(resp, tc.err, 1)
+			if retry != tc.retry {
+				t.Errorf("Expected retry %v, got %v", tc.retry, retry)
+			}
+			if delay != tc.delay {
+				t.Errorf("Expected delay %v, got %v", tc.delay, delay)
+			}
+		})
+	}
+}
+
+// TestWithRetryPolicy tests that a custom policy drives the retry loop and the attempt cap
+func TestWithRetryPolicy(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusTeapot)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var attempts []int
+	client := newTestClient(WithRetryPolicy(func(resp *http.Response, err error, attempt int) (bool, time.Duration) {
+		attempts = append(attempts, attempt)
+		return resp != nil && resp.StatusCode == http.StatusTeapot, time.Millisecond
+	}))
+
+	resp, err := client.Get(server.URL, url.Values{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 after retries, got %d", resp.StatusCode)
+	}
+	if len(attempts) != 2 || attempts[0] != 1 || attempts[1] != 2 {
+		t.Errorf("Expected policy to be consulted for attempts [1 2], got %v", attempts)
+	}
+
+	// The attempt cap wins over a policy that always retries
+	requests.Store(0)
+	client = newTestClient(WithRetryPolicy(func(resp *http.Response, err error, attempt int) (bool, time.Duration) {
+		return true, time.Millisecond
+	}))
+	client.maxAttempts = 2
+
+	// Both attempts get a 418, which is returned as an APIError once the attempts are exhausted
+	_, err = client.Get(server.URL, url.Values{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTeapot {
+		t.Fatalf("Expected an APIError with status 418, got %v", err)
+	}
+
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests with maxAttempts 2, got %d", got)
+	}
+}
+
+// TestWithRetryClassifier tests that a classifier can retry on a body message and is capped by max attempts
+func TestWithRetryClassifier(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 2 {
+			_, _ = w.Write([]byte("Edge: Too Many Requests"))
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	classify := func(resp *http.Response, err error) bool {
+		if err != nil {
+			return true
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return strings.Contains(string(body), "Too Many Requests")
+	}
+
+	client := newTestClient(WithRetryClassifier(classify), WithRetry(3, time.Millisecond))
+	resp, err := client.Get(server.URL, url.Values{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if string(body) != "ok" {
+		t.Errorf("Expected body 'ok' after retry, got %q", body)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests, got %d", got)
+	}
+
+	// The attempt cap still applies to a classifier that always retries
+	requests.Store(0)
+	client = newTestClient(WithRetryClassifier(func(resp *http.Response, err error) bool { return true }), WithRetry(3, time.Millisecond))
+
+	resp, err = client.Get(server.URL, url.Values{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected 3 requests with maxAttempts 3, got %d", got)
+	}
+}
+
+// TestWithContextDeadline tests that a context deadline shorter than the client timeout ends the request first
+func TestWithContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient(WithTimeout(5 * time.Second))}).InstantiateTicker("AAPL")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := ticker.WithContext(ctx).FetchInformation()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the context deadline to end the request early, took %v", elapsed)
+	}
+	if ticker.ctx != nil {
+		t.Error("WithContext should not modify the original ticker")
+	}
+}
+
+// TestWithTimeout tests that the client timeout applies when no context deadline is set
+func TestWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(WithTimeout(50*time.Millisecond), WithRetryPolicy(nil))
+	start := time.Now()
+	if _, err := client.Get(server.URL, url.Values{}); err == nil {
+		t.Fatal("Expected a timeout error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the client timeout to end the request early, took %v", elapsed)
+	}
+}
+
+// TestGzipResponse tests that gzip bodies are decompressed when Accept-Encoding is set explicitly
+func TestGzipResponse(t *testing.T) {
+	var acceptEncoding, userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		userAgent = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		_, _ = gz.Write([]byte(`{"ok":true}`))
+		_ = gz.Close()
+	}))
+	defer server.Close()
+
+	client := newTestClient(WithDefaultHeaders(http.Header{
+		"Accept-Encoding": {"gzip"},
+		"User-Agent":      {"my-app/1.0"},
+	}))
+	resp, err := client.Get(server.URL, url.Values{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Errorf("Expected the decompressed body, got %q", body)
+	}
+	if acceptEncoding != "gzip" || userAgent != "my-app/1.0" {
+		t.Errorf("Expected the default headers to be sent, got Accept-Encoding %q and User-Agent %q", acceptEncoding, userAgent)
+	}
+}
+
+// countingTransport counts the requests sent through it
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// TestWithHTTPClient tests that requests go through the supplied HTTP client and that timeouts are layered correctly
+func TestWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	if timeout := NewClientWithOptions().Client.client.(*http.Client).Timeout; timeout != DefaultTimeout {
+		t.Errorf("Expected the default timeout %v, got %v", DefaultTimeout, timeout)
+	}
+
+	transport := &countingTransport{}
+	hc := &http.Client{Transport: transport}
+	client := newTestClient(WithHTTPClient(hc), WithTimeout(time.Second))
+
+	resp, err := client.Get(server.URL, url.Values{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	if transport.requests.Load() != 1 {
+		t.Errorf("Expected the request to go through the custom transport, got %d requests", transport.requests.Load())
+	}
+	if client.client.(*http.Client).Timeout != time.Second {
+		t.Errorf("Expected timeout 1s, got %v", client.client.(*http.Client).Timeout)
+	}
+	if hc.Timeout != 0 {
+		t.Errorf("WithTimeout should not modify the supplied HTTP client, got timeout %v", hc.Timeout)
+	}
+}
+
+// TestAPIError tests that non-2xx responses become APIErrors carrying the symbol and the start of the body
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("symbols") != "" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, "Too Many Requests")
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `<html>`+strings.Repeat("x", 2*apiErrorBodyLimit)+`</html>`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	client := &YFinanceAPI{Client: newTestClient(WithRetryPolicy(nil))}
+	_, err := client.InstantiateTicker("NOPE").FetchFinancialData()
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an APIError, got %v", err)
+	}
+	var symbolErr *SymbolError
+	if apiErr.StatusCode != http.StatusNotFound || !errors.As(err, &symbolErr) || symbolErr.Symbol != "NOPE" {
+		t.Errorf("Expected status 404 for NOPE, got %d (%v)", apiErr.StatusCode, err)
+	}
+	if len(apiErr.Body) != apiErrorBodyLimit || !strings.HasPrefix(apiErr.Body, "<html>") {
+		t.Errorf("Expected the body truncated to %d bytes, got %d", apiErrorBodyLimit, len(apiErr.Body))
+	}
+	if !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected a 404 to match ErrSymbolNotFound")
+	}
+
+	_, err = client.FetchQuotes([]string{"AAPL"})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected a 429 to match ErrRateLimited, got %v", err)
+	}
+}
+
+// TestWithRetry tests that retryable statuses are retried up to the cap, non-retryable ones fail at once,
+// and a cancelled context stops the backoff
+func TestWithRetry(t *testing.T) {
+	var requests atomic.Int32
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := newTestClient(WithRetry(4, time.Millisecond))
+	_, err := client.Get(server.URL, url.Values{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected an APIError with status 503 after exhausting the attempts, got %v", err)
+	}
+	if got := requests.Load(); got != 4 {
+		t.Errorf("Expected 4 attempts for a 503, got %d", got)
+	}
+
+	requests.Store(0)
+	status = http.StatusNotFound
+	if _, err := client.Get(server.URL, url.Values{}); !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound, got %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected a single attempt for a 404, got %d", got)
+	}
+
+	requests.Store(0)
+	status = http.StatusServiceUnavailable
+	client = newTestClient(WithRetry(5, time.Hour))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetWithContext(ctx, server.URL, url.Values{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the context to interrupt the backoff, got %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected a single attempt before the context expired, got %d", got)
+	}
+}
+
+// TestConcurrentCrumb tests that concurrent first requests share a single crumb fetch without racing
+func TestConcurrentCrumb(t *testing.T) {
+	var crumbRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/test/getcrumb" {
+			crumbRequests.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			fmt.Fprint(w, "fresh-crumb")
+			return
+		}
+		if r.URL.Query().Get("crumb") != "fresh-crumb" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	client := NewClientWithOptions(WithRateLimit(0, 0)).Client
+	client.cookies = []*http.Cookie{{Name: "B", Value: "test"}}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := client.Get(server.URL+"/v7/finance/quote", url.Values{})
+			if err != nil {
+				errs <- err
+				return
+			}
+			resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if got := crumbRequests.Load(); got != 1 {
+		t.Errorf("Expected a single crumb fetch, got %d", got)
+	}
+}
+
+// TestConcurrentFetchesRefreshCrumb tests parallel fetches sharing a client whose first crumb gets rejected:
+// they bootstrap once, refresh once and all succeed, without racing on the cookies and crumb
+func TestConcurrentFetchesRefreshCrumb(t *testing.T) {
+	var crumbRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cookie":
+			http.SetCookie(w, &http.Cookie{Name: "B", Value: "test"})
+		case "/v1/test/getcrumb":
+			fmt.Fprintf(w, "crumb-%d", crumbRequests.Add(1))
+		case "/v7/finance/quote":
+			if r.URL.Query().Get("crumb") != "crumb-2" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"quoteResponse":{"result":[{"symbol":"AAPL","marketState":"REGULAR","regularMarketPrice":190.5}]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	api := NewClientWithOptions(WithRateLimit(0, 0), WithBaseURL(server.URL), WithCookieURL(server.URL+"/cookie"))
+	t.Run("group", func(t *testing.T) {
+		for i := 0; i < 20; i++ {
+			t.Run(fmt.Sprintf("fetch%d", i), func(t *testing.T) {
+				t.Parallel()
+				info, err := api.InstantiateTicker("AAPL").FetchFastInfo()
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if info.LastPrice != 190.5 {
+					t.Errorf("Expected AAPL at 190.5, got %+v", info)
+				}
+			})
+		}
+	})
+
+	if got := crumbRequests.Load(); got != 2 {
+		t.Errorf("Expected the initial crumb fetch and a single refresh, got %d", got)
+	}
+}
+
+// TestWithBaseURL tests that a client sends its cookie, crumb and data requests to the configured hosts
+// without touching the package-wide BaseUrl
+func TestWithBaseURL(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/cookie":
+			http.SetCookie(w, &http.Cookie{Name: "B", Value: "test"})
+		case "/v1/test/getcrumb":
+			fmt.Fprint(w, "mirror-crumb")
+		case "/v7/finance/quote":
+			if r.URL.Query().Get("crumb") != "mirror-crumb" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"quoteResponse":{"result":[{"symbol":"AAPL","regularMarketPrice":190.5}]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	api := NewClientWithOptions(WithRateLimit(0, 0), WithBaseURL(server.URL+"/"), WithCookieURL(server.URL+"/cookie"))
+	quotes, err := api.FetchQuotes([]string{"AAPL"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if price := quotes["AAPL"].RegularMarketPrice; price == nil || price.Raw != 190.5 {
+		t.Errorf("Expected AAPL at 190.5, got %+v", quotes["AAPL"])
+	}
+
+	expected := []string{"/cookie", "/v1/test/getcrumb", "/v7/finance/quote"}
+	if strings.Join(paths, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected requests %v, got %v", expected, paths)
+	}
+	if BaseUrl == server.URL {
+		t.Error("Expected the package-wide BaseUrl to be left alone")
+	}
+}
+
+// TestWithCrumbURL tests fetching the crumb from a separate endpoint
+func TestWithCrumbURL(t *testing.T) {
+	var crumbPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/crumb" || r.URL.Path == "/v1/test/getcrumb" {
+			crumbPath = r.URL.Path
+			fmt.Fprint(w, "proxy-crumb")
+		}
+	}))
+	defer server.Close()
+
+	client := NewClientWithOptions(WithRateLimit(0, 0), WithBaseURL(server.URL), WithCrumbURL(server.URL+"/auth/crumb")).Client
+	client.cookies = []*http.Cookie{{Name: "B", Value: "test"}}
+
+	client.getCrumb(context.Background())
+	if crumb := client.currentCrumb(); crumb != "proxy-crumb" {
+		t.Errorf("Expected proxy-crumb, got %q", crumb)
+	}
+	if crumbPath != "/auth/crumb" {
+		t.Errorf("Expected the crumb to come from /auth/crumb, got %q", crumbPath)
+	}
+}
+
+// TestCrumbErrorResponse tests that error statuses and empty bodies are not stored as the crumb
+func TestCrumbErrorResponse(t *testing.T) {
+	for name, handler := range map[string]http.HandlerFunc{
+		"Rate limited": func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		},
+		"Empty body": func(w http.ResponseWriter, r *http.Request) {},
+	} {
+		server := httptest.NewServer(handler)
+
+		client := NewClientWithOptions(WithRateLimit(0, 0), WithRetryPolicy(nil), WithBaseURL(server.URL)).Client
+		client.cookies = []*http.Cookie{{Name: "B", Value: "test"}}
+
+		client.getCrumb(context.Background())
+		if crumb := client.currentCrumb(); crumb != "" {
+			t.Errorf("%s: expected no crumb, got %q", name, crumb)
+		}
+		server.Close()
+	}
+}
+
+// TestWithBootstrapTimeout tests that a stalled crumb fetch gives up after the bootstrap timeout
+func TestWithBootstrapTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/test/getcrumb" {
+			select {
+			case <-release:
+			case <-r.Context().Done():
+			}
+			return
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	if client := NewClientWithOptions().Client; client.bootstrap != DefaultBootstrapTimeout {
+		t.Errorf("Expected the default bootstrap timeout %v, got %v", DefaultBootstrapTimeout, client.bootstrap)
+	}
+
+	client := NewClientWithOptions(WithRateLimit(0, 0), WithRetry(1, 0), WithBaseURL(server.URL), WithBootstrapTimeout(50*time.Millisecond)).Client
+	client.cookies = []*http.Cookie{{Name: "B", Value: "test"}}
+
+	start := time.Now()
+	client.getCrumb(context.Background())
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the crumb fetch to give up after 50ms, took %v", elapsed)
+	}
+	if client.hasCrumb() {
+		t.Error("Expected no crumb after a timed out fetch")
+	}
+}
+
+// TestRefreshCrumbOnUnauthorized tests that an expired crumb is refreshed once and the request retried
+func TestRefreshCrumbOnUnauthorized(t *testing.T) {
+	var crumbRequests, cookieRequests, dataRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cookie":
+			cookieRequests.Add(1)
+			http.SetCookie(w, &http.Cookie{Name: "A3", Value: "fresh"})
+		case "/v1/test/getcrumb":
+			crumbRequests.Add(1)
+			fmt.Fprint(w, "fresh-crumb")
+		default:
+			dataRequests.Add(1)
+			if crumbs := r.URL.Query()["crumb"]; len(crumbs) != 1 || crumbs[0] != "fresh-crumb" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, "ok")
+		}
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	previousCookieUrl := CookieUrl
+	CookieUrl = server.URL + "/cookie"
+	t.Cleanup(func() { CookieUrl = previousCookieUrl })
+
+	client := newTestClient()
+	params := url.Values{}
+	resp, err := client.Get(server.URL+"/v7/finance/quote", params)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("Expected status 200 after refresh, got %d", resp.StatusCode)
+	}
+	if got := dataRequests.Load(); got != 2 {
+		t.Errorf("Expected the request to be sent twice, got %d", got)
+	}
+	if cookieRequests.Load() != 1 || crumbRequests.Load() != 1 {
+		t.Errorf("Expected one cookie and one crumb fetch, got %d and %d", cookieRequests.Load(), crumbRequests.Load())
+	}
+	if len(params) != 0 {
+		t.Errorf("Expected caller params to be left untouched, got %v", params)
+	}
+}
+
+// TestRefreshCrumbOnce tests that a crumb Yahoo keeps rejecting is refreshed a single time per call
+// and that 403 responses only trigger a refresh when they point at the crumb
+func TestRefreshCrumbOnce(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantRequests int32
+		wantCrumbs   int32
+	}{
+		{"unauthorized", http.StatusUnauthorized, `{"finance":{"error":{"code":"Unauthorized","description":"Invalid Crumb"}}}`, 2, 1},
+		{"forbidden invalid crumb", http.StatusForbidden, `{"finance":{"error":{"description":"Invalid Crumb"}}}`, 2, 1},
+		{"forbidden blocked", http.StatusForbidden, "Access denied", 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var crumbRequests, dataRequests atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/v1/test/getcrumb" {
+					crumbRequests.Add(1)
+					fmt.Fprint(w, "another-crumb")
+					return
+				}
+				if r.URL.Path == "/cookie" {
+					return
+				}
+				dataRequests.Add(1)
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, tt.body)
+			}))
+			defer server.Close()
+			setBaseUrl(t, server.URL)
+
+			previousCookieUrl := CookieUrl
+			CookieUrl = server.URL + "/cookie"
+			t.Cleanup(func() { CookieUrl = previousCookieUrl })
+
+			_, err := newTestClient().Get(server.URL+"/v7/finance/quote", url.Values{})
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Fatalf("Expected an APIError with status %d, got %v", tt.status, err)
+			}
+			if apiErr.Body != tt.body {
+				t.Errorf("Expected body %q in the error, got %q", tt.body, apiErr.Body)
+			}
+			if got := dataRequests.Load(); got != tt.wantRequests {
+				t.Errorf("Expected %d requests, got %d", tt.wantRequests, got)
+			}
+			if got := crumbRequests.Load(); got != tt.wantCrumbs {
+				t.Errorf("Expected %d crumb fetches, got %d", tt.wantCrumbs, got)
+			}
+		})
+	}
+}
+
+// TestRetryAfter tests parsing and capping of the Retry-After header
+func TestRetryAfter(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	if delay := retryAfter(resp); delay != 0 {
+		t.Errorf("Expected zero delay without header, got %v", delay)
+	}
+
+	resp.Header.Set("Retry-After", "3")
+	if delay := retryAfter(resp); delay != 3*time.Second {
+		t.Errorf("Expected 3s delay, got %v", delay)
+	}
+
+	resp.Header.Set("Retry-After", time.Now().Add(5*time.Second).UTC().Format(http.TimeFormat))
+	if delay := retryAfter(resp); delay <= 0 || delay > 5*time.Second {
+		t.Errorf("Expected delay up to 5s, got %v", delay)
+	}
+
+	// A huge value, in seconds or as a far-off date, is capped instead of stalling the request
+	for _, value := range []string{"999999999999", "86400", time.Now().AddDate(1, 0, 0).UTC().Format(http.TimeFormat)} {
+		resp.Header.Set("Retry-After", value)
+		if delay := retryAfter(resp); delay != DefaultRetryMaxDelay {
+			t.Errorf("Expected Retry-After %s to be capped at %v, got %v", value, DefaultRetryMaxDelay, delay)
+		}
+	}
+}
+
+// TestWithLogger tests that internal logs go to the injected logger and are discarded by default
+func TestWithLogger(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+
+	var defaultLogs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&defaultLogs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	doer := &fakeDoer{status: http.StatusNotFound, body: `{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found"}}}`}
+	fetch := func(opts ...Option) {
+		client := &YFinanceAPI{Client: newTestClient(append([]Option{WithDoer(doer), WithRetryPolicy(nil)}, opts...)...)}
+		if _, err := client.InstantiateTicker("NOPE").FetchHistoricalData("1mo", "1d", "", ""); !errors.Is(err, ErrSymbolNotFound) {
+			t.Fatalf("Expected ErrSymbolNotFound, got %v", err)
+		}
+	}
+
+	fetch()
+	fetch(WithLogger(logger), WithSilentLogging())
+	if logs.Len() != 0 || defaultLogs.Len() != 0 {
+		t.Errorf("Expected no logs without a logger, got %q and %q", logs.String(), defaultLogs.String())
+	}
+
+	fetch(WithLogger(logger))
+	if !strings.Contains(logs.String(), "Failed to get historical data") {
+		t.Errorf("Expected the failure in the injected logger, got %q", logs.String())
+	}
+	if defaultLogs.Len() != 0 {
+		t.Errorf("Expected nothing in the default logger, got %q", defaultLogs.String())
+	}
+}
+
+// TestWithProxy tests that the cookie and crumb bootstrap and the requests themselves go through the proxy
+func TestWithProxy(t *testing.T) {
+	var mu sync.Mutex
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		proxied = append(proxied, r.Host+r.URL.Path)
+		mu.Unlock()
+		switch {
+		case r.Host == "fc.invalid":
+			http.SetCookie(w, &http.Cookie{Name: "B", Value: "proxied"})
+		case r.URL.Path == "/v1/test/getcrumb":
+			fmt.Fprint(w, "proxied-crumb")
+		default:
+			fmt.Fprint(w, `{"quoteSummary":{"result":[{"financialData":{"currentPrice":{"raw":1}}}],"error":null}}`)
+		}
+	}))
+	defer proxy.Close()
+
+	client := NewClientWithOptions(WithRateLimit(0, 0), WithBaseURL("http://query.invalid"),
+		WithCookieURL("http://fc.invalid"), WithProxy(proxy.URL))
+	if _, err := client.InstantiateTicker("AAPL").FetchFinancialRatios(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"fc.invalid/", "query.invalid/v1/test/getcrumb", "query.invalid/v10/finance/quoteSummary/AAPL"}
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(proxied, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected %v to go through the proxy, got %v", expected, proxied)
+	}
+}
+
+// TestWithProxyInvalid tests that an invalid proxy URL fails every request without reaching any server
+func TestWithProxyInvalid(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer server.Close()
+
+	for _, proxyURL := range []string{"proxy.corp:3128", "ftp://proxy.corp", "http://%zz"} {
+		client := NewClientWithOptions(WithRateLimit(0, 0), WithBaseURL(server.URL), WithCookieURL(server.URL), WithProxy(proxyURL))
+		if _, err := client.InstantiateTicker("AAPL").FetchFinancialRatios(); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("Expected ErrInvalidParameter for %q, got %v", proxyURL, err)
+		}
+	}
+	if got := requests.Load(); got != 0 {
+		t.Errorf("Expected no request, got %d", got)
+	}
+
+	hc := &http.Client{}
+	client := NewClientWithOptions(WithHTTPClient(hc), WithProxy("socks5://127.0.0.1:1080")).Client
+	if hc.Transport != nil {
+		t.Error("Expected the client passed to WithHTTPClient to be left untouched")
+	}
+	if client.optionErr != nil || client.client.(*http.Client).Transport.(*http.Transport).Proxy == nil {
+		t.Errorf("Expected a SOCKS proxy to be accepted, got %v", client.optionErr)
+	}
+}
+
+// TestUserAgentPinned tests that the cookie, crumb and data requests of a client share one User-Agent
+func TestUserAgentPinned(t *testing.T) {
+	var mu sync.Mutex
+	var userAgents []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		userAgents = append(userAgents, r.Header.Get("User-Agent"))
+		mu.Unlock()
+		if r.URL.Path == "/v1/test/getcrumb" {
+			fmt.Fprint(w, "crumb")
+			return
+		}
+		fmt.Fprint(w, `{"quoteSummary":{"result":[{}],"error":null}}`)
+	}))
+	defer server.Close()
+
+	fetch := func(opts ...Option) []string {
+		mu.Lock()
+		userAgents = nil
+		mu.Unlock()
+
+		client := NewClientWithOptions(append([]Option{WithRateLimit(0, 0), WithBaseURL(server.URL), WithCookieURL(server.URL)}, opts...)...)
+		ticker := client.InstantiateTicker("AAPL")
+		for i := 0; i < 3; i++ {
+			if _, err := ticker.FetchModules([]string{fmt.Sprintf("module%d", i)}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), userAgents...)
+	}
+
+	sent := fetch()
+	if len(sent) != 5 {
+		t.Fatalf("Expected the cookie, crumb and 3 data requests, got %d", len(sent))
+	}
+	for _, userAgent := range sent {
+		if userAgent != sent[0] {
+			t.Errorf("Expected every request to carry %q, got %v", sent[0], sent)
+			break
+		}
+	}
+	known := false
+	for _, userAgent := range UserAgents {
+		known = known || userAgent == sent[0]
+	}
+	if !known {
+		t.Errorf("Expected a User-Agent from UserAgents, got %q", sent[0])
+	}
+
+	for _, userAgent := range fetch(WithUserAgent("my-app/1.0")) {
+		if userAgent != "my-app/1.0" {
+			t.Errorf("Expected the User-Agent set with WithUserAgent, got %q", userAgent)
+		}
+	}
+}
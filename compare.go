@@ -0,0 +1,102 @@
+package yfinance_api
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// RatioRow is one line of a peer comparison table: a single ratio across several symbols.
+// Values are keyed by symbol and are nil when Yahoo has no value for that symbol.
+type RatioRow struct {
+	Metric string              `json:"metric"`
+	Values map[string]*float64 `json:"values"`
+}
+
+// CompareRatios fetches the financial ratios of a peer group concurrently, keyed by symbol.
+// quoteSummary only accepts one symbol per call, so requests fan out with at most DefaultConcurrency in flight.
+// Symbols that fail are left out of the result and reported through a *BatchError.
+func (c *YFinanceAPI) CompareRatios(symbols []string) (map[string]FinancialRatios, error) {
+	// Bootstrap the crumb once up front rather than from every goroutine
+	c.Client.getCrumb(context.Background())
+
+	var mu sync.Mutex
+	ratios := make(map[string]FinancialRatios, len(symbols))
+
+	err := forEachConcurrent(symbols, DefaultConcurrency, func(symbol string) error {
+		symbolRatios, err := c.InstantiateTicker(symbol).FetchFinancialRatios()
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		ratios[symbol] = symbolRatios
+		mu.Unlock()
+		return nil
+	})
+
+	return ratios, err
+}
+
+// FetchFinancialDataBatch fetches the financial data of many symbols concurrently, keyed by symbol,
+// with at most concurrency requests in flight (DefaultConcurrency when not positive).
+// Symbols that fail, or that weren't fetched before ctx was cancelled, are left out of the result
+// and reported through a *BatchError whose Errors map holds the error of each of them.
+func (c *YFinanceAPI) FetchFinancialDataBatch(ctx context.Context, symbols []string, concurrency int) (map[string]FinancialData, error) {
+	// Bootstrap the crumb once up front rather than from every goroutine
+	c.Client.getCrumb(ctx)
+
+	var mu sync.Mutex
+	data := make(map[string]FinancialData, len(symbols))
+
+	err := forEachConcurrent(symbols, concurrency, func(symbol string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		symbolData, err := c.InstantiateTicker(symbol).WithContext(ctx).FetchFinancialData()
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		data[symbol] = symbolData
+		mu.Unlock()
+		return nil
+	})
+
+	return data, err
+}
+
+// PivotRatios turns per-symbol ratios into one row per metric for tabular display.
+// Rows follow the field order of FinancialRatios and use the JSON field names as metric names.
+func PivotRatios(ratios map[string]FinancialRatios) []RatioRow {
+	ratiosType := reflect.TypeOf(FinancialRatios{})
+	priceValueType := reflect.TypeOf(&PriceValue{})
+
+	rows := make([]RatioRow, 0, ratiosType.NumField())
+	for i := 0; i < ratiosType.NumField(); i++ {
+		field := ratiosType.Field(i)
+		if field.Type != priceValueType {
+			continue
+		}
+
+		row := RatioRow{
+			Metric: strings.Split(field.Tag.Get("json"), ",")[0],
+			Values: make(map[string]*float64, len(ratios)),
+		}
+		for symbol, symbolRatios := range ratios {
+			value := reflect.ValueOf(symbolRatios).Field(i).Interface().(*PriceValue)
+			if value == nil {
+				row.Values[symbol] = nil
+				continue
+			}
+			raw := value.Raw
+			row.Values[symbol] = &raw
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
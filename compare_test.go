@@ -0,0 +1,110 @@
+package yfinance_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// TestPivotRatios tests pivoting per-symbol ratios into per-metric rows
+func TestPivotRatios(t *testing.T) {
+	ratios := map[string]FinancialRatios{
+		"AAPL": {PriceToEarningsRatio: &PriceValue{Raw: 30.5}, DividendYield: &PriceValue{Raw: 0.005}},
+		"MSFT": {PriceToEarningsRatio: &PriceValue{Raw: 35.2}},
+	}
+
+	rows := PivotRatios(ratios)
+	if len(rows) == 0 {
+		t.Fatal("Expected pivoted rows")
+	}
+
+	if rows[0].Metric != "priceToEarningsRatio" {
+		t.Errorf("Expected first metric priceToEarningsRatio, got %s", rows[0].Metric)
+	}
+	if *rows[0].Values["AAPL"] != 30.5 || *rows[0].Values["MSFT"] != 35.2 {
+		t.Errorf("Unexpected P/E values: %v", rows[0].Values)
+	}
+
+	for _, row := range rows {
+		if row.Metric != "dividendYield" {
+			continue
+		}
+		if row.Values["AAPL"] == nil || *row.Values["AAPL"] != 0.005 {
+			t.Error("Expected AAPL dividend yield 0.005")
+		}
+		if value, ok := row.Values["MSFT"]; !ok || value != nil {
+			t.Error("Expected a nil MSFT dividend yield entry")
+		}
+	}
+}
+
+// TestCompareRatios tests fetching a peer group's ratios
+func TestCompareRatios(t *testing.T) {
+	client := NewClient()
+
+	ratios, err := client.CompareRatios([]string{"AAPL", "MSFT"})
+	if err != nil {
+		t.Skipf("Skipping test due to API error: %v", err)
+		return
+	}
+
+	if len(ratios) != 2 {
+		t.Errorf("Expected ratios for 2 symbols, got %d", len(ratios))
+	}
+}
+
+// TestFetchFinancialDataBatch tests that symbols are fetched with a single crumb bootstrap
+// and that failures are reported per symbol
+func TestFetchFinancialDataBatch(t *testing.T) {
+	var crumbRequests, dataRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/cookie":
+			http.SetCookie(w, &http.Cookie{Name: "B", Value: "test"})
+		case r.URL.Path == "/v1/test/getcrumb":
+			crumbRequests.Add(1)
+			fmt.Fprint(w, "batch-crumb")
+		case strings.HasSuffix(r.URL.Path, "/UNKNOWN"):
+			dataRequests.Add(1)
+			fmt.Fprint(w, `{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found"}}}`)
+		default:
+			dataRequests.Add(1)
+			fmt.Fprint(w, `{"quoteSummary":{"result":[{"financialData":{"currentPrice":{"raw":100}}}],"error":null}}`)
+		}
+	}))
+	defer server.Close()
+
+	api := NewClientWithOptions(WithRateLimit(0, 0), WithBaseURL(server.URL), WithCookieURL(server.URL+"/cookie"))
+	symbols := []string{"AAPL", "MSFT", "GOOG", "AMZN", "NVDA", "META", "UNKNOWN"}
+
+	data, err := api.FetchFinancialDataBatch(context.Background(), symbols, 3)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected a *BatchError, got %v", err)
+	}
+	if len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors["UNKNOWN"], ErrSymbolNotFound) {
+		t.Errorf("Expected only UNKNOWN to fail, got %v", batchErr.Errors)
+	}
+	if len(data) != len(symbols)-1 {
+		t.Errorf("Expected data for %d symbols, got %d", len(symbols)-1, len(data))
+	}
+	if got := crumbRequests.Load(); got != 1 {
+		t.Errorf("Expected a single crumb fetch, got %d", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	dataRequests.Store(0)
+	data, err = api.FetchFinancialDataBatch(ctx, symbols, 3)
+	if !errors.As(err, &batchErr) || !errors.Is(batchErr.Errors["AAPL"], context.Canceled) {
+		t.Errorf("Expected cancelled symbols to be reported, got %v", err)
+	}
+	if len(data) != 0 || dataRequests.Load() != 0 {
+		t.Errorf("Expected no request after cancellation, got %d results and %d requests", len(data), dataRequests.Load())
+	}
+}
@@ -1,6 +1,13 @@
 package yfinance_api
 
+import "time"
+
 var BaseUrl = "https://query2.finance.yahoo.com"
+
+// CookieUrl is requested once per client to obtain the session cookies the crumb is tied to
+var CookieUrl = "https://fc.yahoo.com"
+
+// UserAgents lists the browser User-Agents a client picks its own from, once when it is created
 var UserAgents = []string{
 	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36",
 	"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36",
@@ -11,3 +18,34 @@ var UserAgents = []string{
 	"Mozilla/5.0 (Macintosh; Intel Mac OS X 14_7_4) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/18.3 Safari/605.1.15",
 	"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/133.0.0.0 Safari/537.36 Edg/131.0.2903.86",
 }
+
+// DefaultTimeout bounds every request of clients that weren't given their own timeout or HTTP client
+var DefaultTimeout = 30 * time.Second
+
+// DefaultBootstrapTimeout bounds fetching the session cookies and crumb, which every first call waits for,
+// so that a stalled bootstrap fails well before DefaultTimeout
+var DefaultBootstrapTimeout = 10 * time.Second
+
+// DefaultMaxAttempts is the number of attempts a request gets, including the first one
+var DefaultMaxAttempts = 3
+
+// DefaultRetryBaseDelay is the initial backoff delay, doubled after every failed attempt
+var DefaultRetryBaseDelay = 500 * time.Millisecond
+
+// DefaultRetryMaxDelay caps the backoff delay between two attempts
+var DefaultRetryMaxDelay = 10 * time.Second
+
+// DefaultConcurrency bounds the number of simultaneous requests made by batch helpers
+var DefaultConcurrency = 4
+
+// DefaultRateLimit is the average number of requests per second a client sends, see WithRateLimit
+var DefaultRateLimit = 5.0
+
+// DefaultRateBurst is the number of requests a client may send at once before DefaultRateLimit applies
+var DefaultRateBurst = 5
+
+// FXRateTTL is how long a client reuses the exchange rates it fetched for currency conversions
+var FXRateTTL = 5 * time.Minute
+
+// QuoteBatchSize is the maximum number of symbols FetchQuotes sends in a single quote request
+var QuoteBatchSize = 50
@@ -0,0 +1,128 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CryptoQuoteType is the quote type Yahoo gives to cryptocurrencies such as "BTC-USD"
+const CryptoQuoteType = "CRYPTOCURRENCY"
+
+// ForexQuoteType is the quote type Yahoo gives to currency pairs such as "EURUSD=X"
+const ForexQuoteType = "CURRENCY"
+
+// IsCrypto reports whether the ticker is a cryptocurrency, whose Volume24Hr, CirculatingSupply
+// and VolumeAllCurrencies fields are meaningful
+func (i YahooTickerInfo) IsCrypto() bool {
+	return i.QuoteType == CryptoQuoteType
+}
+
+// IsForex reports whether the ticker is a currency pair, whose price is an exchange rate
+// and which has no volume or market cap
+func (i YahooTickerInfo) IsForex() bool {
+	return i.QuoteType == ForexQuoteType
+}
+
+// CryptoInfo gathers the fields of YahooTickerInfo that matter for a cryptocurrency
+type CryptoInfo struct {
+	Symbol              string      `json:"symbol"`
+	Name                string      `json:"name"`
+	FromCurrency        string      `json:"fromCurrency"` // Traded coin, e.g. "BTC"
+	ToCurrency          string      `json:"toCurrency"`   // Quote currency, e.g. "USD"
+	Price               *PriceValue `json:"price"`
+	MarketCap           *PriceValue `json:"marketCap"`
+	CirculatingSupply   *PriceValue `json:"circulatingSupply"`
+	Volume24Hr          *PriceValue `json:"volume24Hr"`          // Volume over the last 24 hours, in the quote currency
+	VolumeAllCurrencies *PriceValue `json:"volumeAllCurrencies"` // 24h volume across all quote currencies
+}
+
+// FetchCryptoInfo retrieves the price, market cap, circulating supply and 24h volumes of a cryptocurrency
+// such as "BTC-USD". An error matching ErrInvalidParameter is returned when the symbol isn't a cryptocurrency.
+func (t *Ticker) FetchCryptoInfo() (CryptoInfo, error) {
+	info, err := t.FetchInformation()
+	if err != nil {
+		return CryptoInfo{}, err
+	}
+
+	if !info.IsCrypto() {
+		return CryptoInfo{}, fmt.Errorf("symbol %s is not a cryptocurrency but a %s quote: %w", t.Symbol, info.QuoteType, ErrInvalidParameter)
+	}
+
+	return CryptoInfo{
+		Symbol:              info.Symbol,
+		Name:                info.ShortName,
+		FromCurrency:        stringValue(info.FromCurrency),
+		ToCurrency:          strings.TrimSuffix(stringValue(info.ToCurrency), "=X"),
+		Price:               info.RegularMarketPrice,
+		MarketCap:           info.MarketCap,
+		CirculatingSupply:   info.CirculatingSupply,
+		Volume24Hr:          info.Volume24Hr,
+		VolumeAllCurrencies: info.VolumeAllCurrencies,
+	}, nil
+}
+
+// ForexInfo gathers the fields of YahooTickerInfo that matter for a currency pair
+type ForexInfo struct {
+	Symbol        string      `json:"symbol"`
+	Name          string      `json:"name"`
+	BaseCurrency  string      `json:"baseCurrency"`  // Currency being priced, e.g. "EUR" for "EURUSD=X"
+	QuoteCurrency string      `json:"quoteCurrency"` // Currency the rate is expressed in, e.g. "USD"
+	Rate          *PriceValue `json:"rate"`          // Units of QuoteCurrency per unit of BaseCurrency
+	Change        *PriceValue `json:"change"`
+	ChangePercent *PriceValue `json:"changePercent"`
+	DayLow        *PriceValue `json:"dayLow"`
+	DayHigh       *PriceValue `json:"dayHigh"`
+}
+
+// FetchForexInfo retrieves the exchange rate of a currency pair such as "EURUSD=X", along with its change
+// and day range. An error matching ErrInvalidParameter is returned when the symbol isn't a currency pair.
+func (t *Ticker) FetchForexInfo() (ForexInfo, error) {
+	info, err := t.FetchInformation()
+	if err != nil {
+		return ForexInfo{}, err
+	}
+
+	if !info.IsForex() {
+		return ForexInfo{}, fmt.Errorf("symbol %s is not a currency pair but a %s quote: %w", t.Symbol, info.QuoteType, ErrInvalidParameter)
+	}
+
+	base, quote := forexCurrencies(info)
+	return ForexInfo{
+		Symbol:        info.Symbol,
+		Name:          info.ShortName,
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Rate:          info.RegularMarketPrice,
+		Change:        info.RegularMarketChange,
+		ChangePercent: info.RegularMarketChangePercent,
+		DayLow:        info.RegularMarketDayLow,
+		DayHigh:       info.RegularMarketDayHigh,
+	}, nil
+}
+
+// forexCurrencies returns the base and quote currencies of a pair. Yahoo rarely fills in FromCurrency
+// and ToCurrency for pairs, so they are otherwise read from the symbol: "EURUSD=X" is EUR in USD,
+// while a single code such as "JPY=X" is USD in that currency.
+func forexCurrencies(info YahooTickerInfo) (base, quote string) {
+	base, quote = stringValue(info.FromCurrency), strings.TrimSuffix(stringValue(info.ToCurrency), "=X")
+	if base != "" && quote != "" {
+		return base, quote
+	}
+
+	pair := strings.TrimSuffix(strings.ToUpper(info.Symbol), "=X")
+	switch len(pair) {
+	case 6:
+		return pair[:3], pair[3:]
+	case 3:
+		return "USD", pair
+	}
+	return base, info.Currency
+}
+
+// stringValue dereferences s, returning an empty string for nil
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,74 @@
+package yfinance_api
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestFetchCryptoInfo tests surfacing the crypto fields of the price module
+func TestFetchCryptoInfo(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "BTC-USD", `{"price":{"symbol":"BTC-USD","shortName":"Bitcoin USD",
+		"quoteType":"CRYPTOCURRENCY","fromCurrency":"BTC","toCurrency":"USD=X",
+		"regularMarketPrice":{"raw":67250.5},"marketCap":{"raw":1325000000000},
+		"circulatingSupply":{"raw":19700000},"volume24Hr":{"raw":28500000000},"volumeAllCurrencies":{"raw":31000000000}}}`)
+
+	info, err := ticker.FetchCryptoInfo()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if info.Symbol != "BTC-USD" || info.Name != "Bitcoin USD" || info.FromCurrency != "BTC" || info.ToCurrency != "USD" {
+		t.Errorf("Unexpected identification: %+v", info)
+	}
+	if info.Price.Raw != 67250.5 || info.CirculatingSupply.Raw != 19700000 || info.Volume24Hr.Raw != 28500000000 ||
+		info.VolumeAllCurrencies.Raw != 31000000000 || info.MarketCap.Raw != 1325000000000 {
+		t.Errorf("Unexpected figures: %+v", info)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "AAPL", `{"price":{"symbol":"AAPL","quoteType":"EQUITY"}}`)
+	if _, err := ticker.FetchCryptoInfo(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter for an equity, got %v", err)
+	}
+}
+
+// TestFetchForexInfo tests reading a currency pair, with its currencies taken from the symbol
+func TestFetchForexInfo(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "EURUSD=X", `{"price":{"symbol":"EURUSD=X","shortName":"EUR/USD",
+		"quoteType":"CURRENCY","currency":"USD","fromCurrency":null,"toCurrency":null,
+		"regularMarketPrice":{"raw":1.0842},"regularMarketChange":{"raw":0.0021},"regularMarketDayLow":{"raw":1.0811}}}`)
+
+	info, err := ticker.FetchForexInfo()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if info.BaseCurrency != "EUR" || info.QuoteCurrency != "USD" || info.Rate.Float() != 1.0842 || info.DayLow.Float() != 1.0811 {
+		t.Errorf("Unexpected forex info: %+v", info)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "BTC-USD", `{"price":{"symbol":"BTC-USD","quoteType":"CRYPTOCURRENCY"}}`)
+	if _, err := ticker.FetchForexInfo(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter for a cryptocurrency, got %v", err)
+	}
+}
+
+// TestForexCurrencies tests telling the base and quote currencies of a pair apart
+func TestForexCurrencies(t *testing.T) {
+	usd, gbp := "USD=X", "GBP"
+	testCases := []struct {
+		info        YahooTickerInfo
+		base, quote string
+	}{
+		{info: YahooTickerInfo{Symbol: "GBPJPY=X"}, base: "GBP", quote: "JPY"},
+		{info: YahooTickerInfo{Symbol: "JPY=X", Currency: "JPY"}, base: "USD", quote: "JPY"},
+		{info: YahooTickerInfo{Symbol: "GBPUSD=X", FromCurrency: &gbp, ToCurrency: &usd}, base: "GBP", quote: "USD"},
+	}
+	for _, tc := range testCases {
+		if base, quote := forexCurrencies(tc.info); base != tc.base || quote != tc.quote {
+			t.Errorf("%s: expected %s/%s, got %s/%s", tc.info.Symbol, tc.base, tc.quote, base, quote)
+		}
+	}
+
+	if !(YahooTickerInfo{QuoteType: ForexQuoteType}).IsForex() || (YahooTickerInfo{QuoteType: "EQUITY"}).IsCrypto() {
+		t.Error("Unexpected quote type classification")
+	}
+}
@@ -0,0 +1,172 @@
+package yfinance_api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNoData is returned when Yahoo Finance answers successfully but does not provide the requested value.
+// Use errors.Is to tell it apart from network and decoding failures.
+var ErrNoData = errors.New("no data available")
+
+// ErrSymbolNotFound is returned when Yahoo Finance does not know the requested symbol
+var ErrSymbolNotFound = errors.New("symbol not found")
+
+// ErrInvalidParameter is returned when Yahoo Finance rejects a request parameter, such as an unsupported range or interval
+var ErrInvalidParameter = errors.New("invalid parameter")
+
+// ErrRateLimited is returned when Yahoo Finance keeps answering 429 Too Many Requests after the retries
+var ErrRateLimited = errors.New("rate limited")
+
+// ErrUnauthorized is returned when Yahoo Finance keeps rejecting the cookies and crumb, even after refreshing them
+var ErrUnauthorized = errors.New("unauthorized")
+
+// apiErrorBodyLimit is the number of body bytes kept in an APIError for debugging
+const apiErrorBodyLimit = 512
+
+// APIError is returned when Yahoo Finance answers with a non-2xx status code.
+// Common status codes unwrap to ErrSymbolNotFound, ErrRateLimited, ErrInvalidParameter or ErrUnauthorized,
+// and the error object of the body, when Yahoo sent one, is available as a *YahooError through errors.As.
+type APIError struct {
+	StatusCode int
+	Body       string      // Beginning of the response body
+	Yahoo      *YahooError // Error object of the body, nil when the body doesn't carry one
+}
+
+func (e *APIError) Error() string {
+	message := fmt.Sprintf("yahoo finance API error: status %d", e.StatusCode)
+	switch {
+	case e.Yahoo != nil:
+		message += ": " + e.Yahoo.message()
+	case e.Body != "":
+		message += ": " + e.Body
+	}
+	return message
+}
+
+// Unwrap maps the status code to a sentinel error and exposes the Yahoo error object,
+// so callers can use errors.Is and errors.As
+func (e *APIError) Unwrap() []error {
+	var errs []error
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		errs = append(errs, ErrSymbolNotFound)
+	case http.StatusTooManyRequests:
+		errs = append(errs, ErrRateLimited)
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		errs = append(errs, ErrInvalidParameter)
+	case http.StatusUnauthorized, http.StatusForbidden:
+		errs = append(errs, ErrUnauthorized)
+	}
+	if e.Yahoo != nil {
+		errs = append(errs, e.Yahoo)
+	}
+	return errs
+}
+
+// newAPIError builds an APIError from a non-2xx response, reading the beginning of its body
+// and closing it
+func newAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, apiErrorBodyLimit))
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	return &APIError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body)), Yahoo: parseYahooError(body)}
+}
+
+// parseYahooError extracts the error object of a Yahoo response body, which is nested under the name
+// of the endpoint, e.g. {"chart":{"result":null,"error":{...}}}. It returns nil when there is none,
+// including when the body isn't JSON or was truncated.
+func parseYahooError(body []byte) *YahooError {
+	var envelope map[string]struct {
+		Error interface{} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil
+	}
+	for _, response := range envelope {
+		if yahooErr := newYahooError(response.Error); yahooErr != nil {
+			return yahooErr
+		}
+	}
+	return nil
+}
+
+// YahooError is the error payload Yahoo Finance returns alongside an empty result,
+// e.g. {"code":"Not Found","description":"No data found, symbol may be delisted"}.
+// Common codes unwrap to ErrSymbolNotFound, ErrInvalidParameter, ErrUnauthorized or ErrRateLimited.
+type YahooError struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+func (e *YahooError) Error() string {
+	return "yahoo finance error: " + e.message()
+}
+
+// message is the code followed by the description, if any
+func (e *YahooError) message() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// Unwrap maps the error code to a sentinel error so callers can use errors.Is
+func (e *YahooError) Unwrap() error {
+	switch e.Code {
+	case "Not Found":
+		return ErrSymbolNotFound
+	case "Bad Request", "Unprocessable Entity":
+		return ErrInvalidParameter
+	case "Unauthorized", "Forbidden":
+		return ErrUnauthorized
+	case "Too Many Requests":
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// SymbolError ties an error to the symbol it is about, so that a batch loop can tell which ticker is invalid
+// with errors.As and skip it. It unwraps to the underlying error, e.g. ErrSymbolNotFound or a *YahooError.
+type SymbolError struct {
+	Symbol string
+	Err    error
+}
+
+func (e *SymbolError) Error() string {
+	return fmt.Sprintf("symbol %s: %v", e.Symbol, e.Err)
+}
+
+func (e *SymbolError) Unwrap() error {
+	return e.Err
+}
+
+// emptyResultError explains an empty result: Yahoo's own error when it sent one, ErrSymbolNotFound otherwise,
+// as Yahoo answers unknown symbols with an empty result
+func emptyResultError(what, symbol string, payload interface{}) error {
+	if yahooErr := newYahooError(payload); yahooErr != nil {
+		return &SymbolError{Symbol: symbol, Err: fmt.Errorf("failed to get %s: %w", what, yahooErr)}
+	}
+	return &SymbolError{Symbol: symbol, Err: fmt.Errorf("no %s found: %w", what, ErrSymbolNotFound)}
+}
+
+// newYahooError converts the loosely typed error field of a Yahoo response into a *YahooError,
+// returning nil when there is no error
+func newYahooError(payload interface{}) *YahooError {
+	fields, ok := payload.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	code, _ := fields["code"].(string)
+	description, _ := fields["description"].(string)
+	if code == "" && description == "" {
+		return nil
+	}
+	return &YahooError{Code: code, Description: description}
+}
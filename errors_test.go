@@ -0,0 +1,229 @@
+package yfinance_api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestYahooError tests mapping Yahoo error codes to sentinel errors
+func TestYahooError(t *testing.T) {
+	testCases := []struct {
+		name     string
+		payload  interface{}
+		sentinel error
+	}{
+		{name: "Not found", payload: map[string]interface{}{"code": "Not Found", "description": "No data found, symbol may be delisted"}, sentinel: ErrSymbolNotFound},
+		{name: "Bad request", payload: map[string]interface{}{"code": "Bad Request", "description": "Invalid input - interval=7m is not supported"}, sentinel: ErrInvalidParameter},
+		{name: "Unauthorized", payload: map[string]interface{}{"code": "Unauthorized", "description": "Invalid Crumb"}, sentinel: ErrUnauthorized},
+		{name: "Unknown code", payload: map[string]interface{}{"code": "Internal Server Error"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			yahooErr := newYahooError(tc.payload)
+			if yahooErr == nil {
+				t.Fatal("Expected a YahooError")
+			}
+			if tc.sentinel != nil && !errors.Is(yahooErr, tc.sentinel) {
+				t.Errorf("Expected %v to match %v", yahooErr, tc.sentinel)
+			}
+			if tc.sentinel == nil && (errors.Is(yahooErr, ErrSymbolNotFound) || errors.Is(yahooErr, ErrInvalidParameter)) {
+				t.Errorf("Expected %v to match no sentinel", yahooErr)
+			}
+		})
+	}
+
+	if yahooErr := newYahooError(nil); yahooErr != nil {
+		t.Errorf("Expected nil for a null error payload, got %v", yahooErr)
+	}
+}
+
+// TestFetchChartError tests that the chart error payload is surfaced as a typed error
+func TestFetchChartError(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("NOPE")
+	_, err := ticker.FetchHistoricalData("1mo", "1d", "", "")
+	if !errors.Is(err, ErrSymbolNotFound) {
+		t.Fatalf("Expected ErrSymbolNotFound, got %v", err)
+	}
+
+	var yahooErr *YahooError
+	if !errors.As(err, &yahooErr) || yahooErr.Code != "Not Found" {
+		t.Errorf("Expected a YahooError with code Not Found, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "symbol may be delisted") {
+		t.Errorf("Expected the description in the message, got %q", err.Error())
+	}
+}
+
+// TestEmptyQuoteSummaryResult tests that an empty quoteSummary result without explanation means an unknown symbol
+func TestEmptyQuoteSummaryResult(t *testing.T) {
+	newChartServer(t, `{"quoteSummary":{"result":[],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("NOPE")
+	if _, err := ticker.FetchFinancialRatios(); !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound from FetchFinancialRatios, got %v", err)
+	}
+	if _, err := ticker.FetchCalendar(); !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound from FetchCalendar, got %v", err)
+	}
+}
+
+// TestAPIErrorYahooPayload tests that the error object of a non-2xx body is parsed, so that a delisted symbol,
+// an invalid range and a rejected crumb can be told apart
+func TestAPIErrorYahooPayload(t *testing.T) {
+	testCases := []struct {
+		name     string
+		status   int
+		body     string
+		fetch    func(ticker *Ticker) error
+		code     string
+		sentinel error
+	}{
+		{
+			name:   "Delisted symbol",
+			status: http.StatusNotFound,
+			body:   `{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`,
+			fetch: func(ticker *Ticker) error {
+				_, err := ticker.FetchHistoricalData("1mo", "1d", "", "")
+				return err
+			},
+			code:     "Not Found",
+			sentinel: ErrSymbolNotFound,
+		},
+		{
+			name:   "Invalid range",
+			status: http.StatusUnprocessableEntity,
+			body:   `{"chart":{"result":null,"error":{"code":"Unprocessable Entity","description":"1m data not available for startTime"}}}`,
+			fetch: func(ticker *Ticker) error {
+				_, err := ticker.FetchHistoricalData("1y", "1m", "", "")
+				return err
+			},
+			code:     "Unprocessable Entity",
+			sentinel: ErrInvalidParameter,
+		},
+		{
+			name:   "Quote summary",
+			status: http.StatusNotFound,
+			body:   `{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found for symbol: NOPE"}}}`,
+			fetch: func(ticker *Ticker) error {
+				_, err := ticker.FetchInformation()
+				return err
+			},
+			code:     "Not Found",
+			sentinel: ErrSymbolNotFound,
+		},
+		{
+			name:   "Financials",
+			status: http.StatusNotFound,
+			body:   `{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found for symbol: NOPE"}}}`,
+			fetch: func(ticker *Ticker) error {
+				_, err := ticker.FetchIncomeStatement()
+				return err
+			},
+			code:     "Not Found",
+			sentinel: ErrSymbolNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+			setBaseUrl(t, server.URL)
+
+			ticker := (&YFinanceAPI{Client: newTestClient(WithRetryPolicy(nil))}).InstantiateTicker("NOPE")
+			err := tc.fetch(ticker)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Yahoo == nil {
+				t.Fatalf("Expected an APIError carrying the Yahoo error, got %v", err)
+			}
+			var yahooErr *YahooError
+			if !errors.As(err, &yahooErr) || yahooErr.Code != tc.code {
+				t.Errorf("Expected a YahooError with code %q, got %v", tc.code, err)
+			}
+			if !errors.Is(err, tc.sentinel) {
+				t.Errorf("Expected %v to match %v", err, tc.sentinel)
+			}
+			if !strings.Contains(err.Error(), yahooErr.Description) || strings.Contains(err.Error(), "{") {
+				t.Errorf("Expected the description instead of the raw body in %q", err.Error())
+			}
+		})
+	}
+}
+
+// TestAPIErrorUnauthorized tests that a crumb still rejected after the refresh unwraps to ErrUnauthorized
+func TestAPIErrorUnauthorized(t *testing.T) {
+	apiErr := &APIError{StatusCode: http.StatusUnauthorized, Yahoo: parseYahooError([]byte(`{"finance":{"result":null,"error":{"code":"Unauthorized","description":"Invalid Crumb"}}}`))}
+	if !errors.Is(apiErr, ErrUnauthorized) {
+		t.Errorf("Expected %v to match ErrUnauthorized", apiErr)
+	}
+	if apiErr.Yahoo == nil || apiErr.Yahoo.Description != "Invalid Crumb" {
+		t.Errorf("Expected the Invalid Crumb error object, got %+v", apiErr.Yahoo)
+	}
+
+	for _, body := range []string{"", "Too Many Requests", `{"chart":{"result":null,"error":null}}`, `{"chart":{"error":{"code":"Not Fou`} {
+		if yahooErr := parseYahooError([]byte(body)); yahooErr != nil {
+			t.Errorf("Expected no error object in %q, got %v", body, yahooErr)
+		}
+	}
+}
+
+// TestSymbolError tests that an unknown symbol can be identified without matching on the message
+func TestSymbolError(t *testing.T) {
+	newChartServer(t, `{"quoteSummary":{"result":[],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("NOPE")
+	_, err := ticker.FetchFinancialData()
+
+	var symbolErr *SymbolError
+	if !errors.As(err, &symbolErr) || symbolErr.Symbol != "NOPE" {
+		t.Fatalf("Expected a SymbolError for NOPE, got %v", err)
+	}
+	if !errors.Is(err, ErrSymbolNotFound) || errors.Is(err, ErrNoData) {
+		t.Errorf("Expected %v to match ErrSymbolNotFound only", err)
+	}
+}
+
+// TestSymbolErrorStatus tests that an error status is tied to the ticker's symbol as well
+func TestSymbolErrorStatus(t *testing.T) {
+	doer := &fakeDoer{status: http.StatusNotFound, body: `{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found"}}}`}
+	ticker := (&YFinanceAPI{Client: newTestClient(WithDoer(doer), WithRetryPolicy(nil))}).InstantiateTicker("NOPE")
+	_, err := ticker.FetchHistoricalData("1mo", "1d", "", "")
+
+	var symbolErr *SymbolError
+	if !errors.As(err, &symbolErr) || symbolErr.Symbol != "NOPE" {
+		t.Fatalf("Expected a SymbolError for NOPE, got %v", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected the 404 APIError to stay reachable, got %v", err)
+	}
+}
+
+// TestYahooErrorOutsideQuoteSummary tests that the error object of the market summary and quote endpoints is surfaced
+func TestYahooErrorOutsideQuoteSummary(t *testing.T) {
+	newChartServer(t, `{"marketSummaryResponse":{"result":[],"error":{"code":"Bad Request","description":"Invalid region"}},
+		"quoteResponse":{"result":[],"error":{"code":"Too Many Requests","description":"Rate limited"}}}`)
+	client := &YFinanceAPI{Client: newTestClient()}
+
+	_, err := client.FetchMarketSummary("XX")
+	var yahooErr *YahooError
+	if !errors.As(err, &yahooErr) || !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected a YahooError matching ErrInvalidParameter, got %v", err)
+	}
+
+	_, err = client.FetchQuotes([]string{"AAPL"})
+	if !errors.As(err, &yahooErr) || !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected a YahooError matching ErrRateLimited, got %v", err)
+	}
+}
@@ -0,0 +1,357 @@
+package yfinance_api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// WriteHistoryCSV writes historical data as CSV with a Date,Open,High,Low,Close,Volume header, oldest first.
+// Missing values are written as empty fields.
+func WriteHistoryCSV(w io.Writer, data map[string]PriceData) error {
+	dates := make([]string, 0, len(data))
+	for date := range data {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"Date", "Open", "High", "Low", "Close", "Volume"}); err != nil {
+		return err
+	}
+	for _, date := range dates {
+		price := data[date]
+		record := []string{date, csvFloat(price.Open), csvFloat(price.High), csvFloat(price.Low), csvFloat(price.Close), csvInt(price.Volume)}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// StreamHistoricalData writes the price series of the ticker to w as JSON Lines, one Candle object per line
+// in chronological order, without building the date-keyed map of FetchHistoricalData. It takes the same range
+// and interval values, with the same defaults. The response is decoded from the connection value by value
+// rather than read whole; as Yahoo sends the series column by column, the first line is only written once
+// every column has arrived. When w has a Flush method (bufio.Writer, http.Flusher...) it is flushed after
+// every line so that readers get the candles as they are written.
+// Cancelling ctx aborts the request, or stops the output between two lines.
+func (t *Ticker) StreamHistoricalData(ctx context.Context, w io.Writer, rangeParam, interval string) error {
+	endpoint := fmt.Sprintf("%s/v8/finance/chart/%s", t.Client.baseURL(), t.Symbol)
+
+	resp, err := t.WithContext(ctx).get(endpoint, historyParams(rangeParam, interval, "", ""))
+	if err != nil {
+		t.Client.log().Error("Failed to get historical data", "err", err)
+		return err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.Client.log().Error("Failed to close response body", "err", err)
+		}
+	}(resp.Body)
+
+	historyResponse, payload, err := decodeChartColumns(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to decode history data JSON response: %v", err)
+	}
+	if len(historyResponse.Chart.Result) == 0 {
+		return emptyResultError("chart", t.Symbol, payload)
+	}
+	meta := historyResponse.Chart.Result[0].Meta
+	if meta.ExchangeTimezoneName != "" {
+		t.Client.locations.Store(t.Symbol, exchangeLocation(meta.ExchangeTimezoneName, meta.Gmtoffset))
+	}
+
+	encoder := json.NewEncoder(w)
+	return eachCandle(historyResponse, func(candle Candle) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := encoder.Encode(candle); err != nil {
+			return err
+		}
+		return flushWriter(w)
+	})
+}
+
+// decodeChartColumns decodes the meta, timestamps and price columns of the first chart result from r,
+// one array element at a time, skipping the events and any other result. The error payload is returned
+// alongside, for emptyResultError.
+func decodeChartColumns(r io.Reader) (YahooHistoryResponse, interface{}, error) {
+	var historyResponse YahooHistoryResponse
+	var payload interface{}
+	var hasResult, hasQuote, hasAdjclose bool
+	dec := json.NewDecoder(r)
+
+	// Decode into a single result with a single quote and adjclose, each dropped again when the response has none
+	historyResponse.Chart.Result = appendZero(historyResponse.Chart.Result)
+	result := &historyResponse.Chart.Result[0]
+	result.Indicators.Quote = appendZero(result.Indicators.Quote)
+	result.Indicators.Adjclose = appendZero(result.Indicators.Adjclose)
+	quote := &result.Indicators.Quote[0]
+	adjclose := &result.Indicators.Adjclose[0]
+
+	quoteField := func(key string) error {
+		switch key {
+		case "open":
+			return decodeColumn(dec, &quote.Open)
+		case "high":
+			return decodeColumn(dec, &quote.High)
+		case "low":
+			return decodeColumn(dec, &quote.Low)
+		case "close":
+			return decodeColumn(dec, &quote.Close)
+		case "volume":
+			return decodeColumn(dec, &quote.Volume)
+		}
+		return skipValue(dec)
+	}
+	adjcloseField := func(key string) error {
+		if key != "adjclose" {
+			return skipValue(dec)
+		}
+		return decodeColumn(dec, &adjclose.Adjclose)
+	}
+	indicatorsField := func(key string) error {
+		switch key {
+		case "quote":
+			return decodeFirst(dec, &hasQuote, quoteField)
+		case "adjclose":
+			return decodeFirst(dec, &hasAdjclose, adjcloseField)
+		}
+		return skipValue(dec)
+	}
+	resultField := func(key string) error {
+		switch key {
+		case "meta":
+			return dec.Decode(&result.Meta)
+		case "timestamp":
+			return decodeColumn(dec, &result.Timestamp)
+		case "indicators":
+			return decodeObject(dec, indicatorsField)
+		}
+		return skipValue(dec)
+	}
+	chartField := func(key string) error {
+		switch key {
+		case "result":
+			return decodeFirst(dec, &hasResult, resultField)
+		case "error":
+			return dec.Decode(&payload)
+		}
+		return skipValue(dec)
+	}
+
+	// {"chart":{"result":[{"meta":{...},"timestamp":[...],"indicators":{"quote":[{...}],"adjclose":[{...}]}}],"error":null}}
+	err := decodeObject(dec, func(key string) error {
+		if key != "chart" {
+			return skipValue(dec)
+		}
+		return decodeObject(dec, chartField)
+	})
+	if err != nil {
+		return YahooHistoryResponse{}, nil, err
+	}
+
+	if !hasQuote {
+		result.Indicators.Quote = nil
+	}
+	if !hasAdjclose {
+		result.Indicators.Adjclose = nil
+	}
+	if !hasResult {
+		historyResponse.Chart.Result = nil
+	}
+	return historyResponse, payload, nil
+}
+
+// decodeFirst decodes the first object of the next array through field, setting found, and skips the others
+func decodeFirst(dec *json.Decoder, found *bool, field func(key string) error) error {
+	return decodeArray(dec, func(i int) error {
+		if i > 0 {
+			return skipValue(dec)
+		}
+		*found = true
+		return decodeObject(dec, field)
+	})
+}
+
+// decodeObject calls field for each key of the next object, which must consume the value of the key.
+// A null object has no keys.
+func decodeObject(dec *json.Decoder, field func(key string) error) error {
+	if ok, err := openContainer(dec, '{'); !ok || err != nil {
+		return err
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := token.(string)
+		if err := field(key); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, '}')
+}
+
+// decodeArray calls element with the index of each element of the next array, which must consume the element.
+// A null array has no elements.
+func decodeArray(dec *json.Decoder, element func(i int) error) error {
+	if ok, err := openContainer(dec, '['); !ok || err != nil {
+		return err
+	}
+	for i := 0; dec.More(); i++ {
+		if err := element(i); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+// decodeColumn appends the elements of the next array to values, decoding them one at a time
+func decodeColumn[T any](dec *json.Decoder, values *[]T) error {
+	return decodeArray(dec, func(int) error {
+		var value T
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		*values = append(*values, value)
+		return nil
+	})
+}
+
+// openContainer reads the opening delimiter of the next object or array, reporting false for null
+func openContainer(dec *json.Decoder, open json.Delim) (bool, error) {
+	token, err := dec.Token()
+	if err != nil || token == nil {
+		return false, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != open {
+		return false, fmt.Errorf("expected %v, got %v", open, token)
+	}
+	return true, nil
+}
+
+// appendZero appends the zero value to values, for slices of anonymous struct types
+func appendZero[T any](values []T) []T {
+	var zero T
+	return append(values, zero)
+}
+
+// flushWriter flushes w when it buffers its output
+func flushWriter(w io.Writer) error {
+	switch flusher := w.(type) {
+	case interface{ Flush() error }:
+		return flusher.Flush()
+	case http.Flusher:
+		flusher.Flush()
+	}
+	return nil
+}
+
+// DownloadHistoryToDir fetches the history of each symbol concurrently and writes it to dir/SYMBOL.csv,
+// creating dir if needed. At most concurrency downloads run at once (DefaultConcurrency when not positive).
+// Symbols that fail are skipped and reported through a *BatchError once all the others are written.
+func (c *YFinanceAPI) DownloadHistoryToDir(dir string, symbols []string, rangeParam, interval string, concurrency int) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	// Bootstrap the crumb once up front rather than from every goroutine
+	c.Client.getCrumb(context.Background())
+
+	return forEachConcurrent(symbols, concurrency, func(symbol string) error {
+		if filepath.Base(symbol) != symbol || symbol == ".." {
+			return fmt.Errorf("invalid symbol for a file name: %q", symbol)
+		}
+
+		data, err := c.InstantiateTicker(symbol).FetchHistoricalData(rangeParam, interval, "", "")
+		if err != nil {
+			return err
+		}
+
+		return writeHistoryFile(filepath.Join(dir, symbol+".csv"), data)
+	})
+}
+
+// writeHistoryFile writes the historical data as CSV to path, replacing any existing file
+func writeHistoryFile(path string, data map[string]PriceData) error {
+	file, err := os.OpenFile(filepath.Clean(path), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	if err := WriteHistoryCSV(file, data); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return file.Close()
+}
+
+func csvFloat(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*f, 'f', -1, 64)
+}
+
+func csvInt(i *int64) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.FormatInt(*i, 10)
+}
+
+// financialDataFields has the fields of FinancialData without its JSON methods
+type financialDataFields FinancialData
+
+// financialDataJSON is the JSON form of FinancialData, which also records the available sections
+type financialDataJSON struct {
+	financialDataFields
+	Sections []FinancialSection `json:"sections"`
+}
+
+// MarshalJSON encodes the financial data along with its available sections, so that data cached to disk
+// still reports them once decoded. Missing values are encoded as null rather than omitted, so every
+// symbol shares the same schema.
+func (f FinancialData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(financialDataJSON{financialDataFields: financialDataFields(f), Sections: f.AvailableSections()})
+}
+
+// UnmarshalJSON decodes financial data encoded by MarshalJSON, restoring its available sections
+func (f *FinancialData) UnmarshalJSON(data []byte) error {
+	var decoded financialDataJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*f = FinancialData(decoded.financialDataFields)
+	f.sections = decoded.Sections
+	return nil
+}
+
+// MarshalJSONIndented encodes the financial data as JSON indented with two spaces, with fields in a fixed
+// order, so that files written for the same data are identical
+func (f FinancialData) MarshalJSONIndented() ([]byte, error) {
+	return json.MarshalIndent(f, "", "  ")
+}
+
+// WriteFinancialDataJSON writes the financial data to w as MarshalJSONIndented does, followed by a newline
+func WriteFinancialDataJSON(w io.Writer, d FinancialData) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(d)
+}
@@ -0,0 +1,201 @@
+package yfinance_api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestWriteHistoryCSV tests the CSV layout, ordering and empty fields
+func TestWriteHistoryCSV(t *testing.T) {
+	data := map[string]PriceData{
+		"2024-01-03": {Open: floatPtr(2), High: floatPtr(2.5), Low: floatPtr(1.5), Close: floatPtr(2.25), Volume: int64Ptr(200)},
+		"2024-01-02": {Open: floatPtr(1), High: floatPtr(1.5), Low: floatPtr(0.5), Close: floatPtr(1.25), Volume: int64Ptr(100)},
+		"2024-01-04": {},
+	}
+
+	var buf strings.Builder
+	if err := WriteHistoryCSV(&buf, data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "Date,Open,High,Low,Close,Volume\n" +
+		"2024-01-02,1,1.5,0.5,1.25,100\n" +
+		"2024-01-03,2,2.5,1.5,2.25,200\n" +
+		"2024-01-04,,,,,\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected CSV:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+// TestDownloadHistoryToDir tests writing one file per symbol and reporting failures
+func TestDownloadHistoryToDir(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/NOPE") {
+			fmt.Fprint(w, `{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found"}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-18000},
+			"timestamp":[1704205800],
+			"indicators":{"quote":[{"open":[1],"high":[2],"low":[0.5],"close":[1.5],"volume":[100]}]}}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	dir := filepath.Join(t.TempDir(), "history")
+	client := &YFinanceAPI{Client: newTestClient()}
+	err := client.DownloadHistoryToDir(dir, []string{"AAPL", "MSFT", "NOPE"}, "1mo", "1d", 2)
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 || !errors.Is(batchErr.Errors["NOPE"], ErrSymbolNotFound) {
+		t.Fatalf("Expected a BatchError for NOPE only, got %v", err)
+	}
+
+	for _, symbol := range []string{"AAPL", "MSFT"} {
+		content, err := os.ReadFile(filepath.Join(dir, symbol+".csv"))
+		if err != nil {
+			t.Fatalf("Expected %s.csv to be written: %v", symbol, err)
+		}
+		if !strings.Contains(string(content), "2024-01-02,1,2,0.5,1.5,100") {
+			t.Errorf("Unexpected content for %s:\n%s", symbol, content)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "NOPE.csv")); !os.IsNotExist(err) {
+		t.Error("Expected no file for the failed symbol")
+	}
+}
+
+// cancellingWriter cancels a context once it has received a given number of lines
+type cancellingWriter struct {
+	lines  []string
+	after  int
+	cancel context.CancelFunc
+}
+
+func (w *cancellingWriter) Write(p []byte) (int, error) {
+	w.lines = append(w.lines, string(p))
+	if len(w.lines) == w.after {
+		w.cancel()
+	}
+	return len(p), nil
+}
+
+// TestStreamHistoricalData tests writing one candle per line, flushing and stopping on cancellation
+func TestStreamHistoricalData(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"UTC"},
+		"timestamp":[1704153600,1704240000,1704326400],
+		"indicators":{"quote":[{"open":[1,2,3],"high":[1.5,2.5,3.5],"low":[0.5,1.5,2.5],"close":[1.25,null,3.25],"volume":[100,200,300]}]}}],"error":null}}`)
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+
+	var out strings.Builder
+	buffered := bufio.NewWriterSize(&out, 4096)
+	if err := ticker.StreamHistoricalData(context.Background(), buffered, "5d", "1d"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected 3 flushed lines, got %d: %q", len(lines), out.String())
+	}
+	var candle Candle
+	if err := json.Unmarshal([]byte(lines[1]), &candle); err != nil {
+		t.Fatalf("Unexpected error decoding %q: %v", lines[1], err)
+	}
+	if *candle.Open != 2 || candle.Close != nil || *candle.Volume != 200 || candle.Time.Unix() != 1704240000 {
+		t.Errorf("Unexpected second candle: %s", lines[1])
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	writer := &cancellingWriter{after: 1, cancel: cancel}
+	if err := ticker.StreamHistoricalData(ctx, writer, "5d", "1d"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if len(writer.lines) != 1 {
+		t.Errorf("Expected the stream to stop after 1 line, got %d", len(writer.lines))
+	}
+}
+
+// TestDecodeChartColumns tests decoding the first result column by column, skipping events and later results
+func TestDecodeChartColumns(t *testing.T) {
+	body := `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"UTC","gmtoffset":0},"timestamp":[1704153600,1704240000],
+		"events":{"dividends":{"1704153600":{"amount":0.24,"date":1704153600}}},
+		"indicators":{"quote":[{"open":[1,null],"close":[1.5,2.5],"volume":[100,null]}],"adjclose":[{"adjclose":[1.4,2.4]}]}},
+		{"meta":{"exchangeTimezoneName":"Europe/Paris"},"timestamp":[1]}],"error":null}}`
+	historyResponse, _, err := decodeChartColumns(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(historyResponse.Chart.Result) != 1 {
+		t.Fatalf("Expected 1 result, got %d", len(historyResponse.Chart.Result))
+	}
+	series := candles(historyResponse)
+	if len(series) != 2 || series[1].Open != nil || *series[1].Close != 2.5 || *series[1].AdjClose != 2.4 || series[1].Volume != nil {
+		t.Errorf("Unexpected candles: %+v", series)
+	}
+	if historyResponse.Chart.Result[0].Meta.ExchangeTimezoneName != "UTC" {
+		t.Errorf("Expected the meta of the first result, got %+v", historyResponse.Chart.Result[0].Meta)
+	}
+
+	_, payload, err := decodeChartColumns(strings.NewReader(`{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found"}}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var yahooErr *YahooError
+	if err := emptyResultError("chart", "NOPE", payload); !errors.As(err, &yahooErr) || yahooErr.Code != "Not Found" {
+		t.Errorf("Expected Yahoo's error, got %v", err)
+	}
+
+	if _, _, err := decodeChartColumns(strings.NewReader(`{"chart":{"result":[{"timestamp":[1,`)); err == nil {
+		t.Error("Expected an error for a truncated response")
+	}
+}
+
+// TestWriteFinancialDataJSON tests that the JSON keeps missing values as null and round-trips the sections
+func TestWriteFinancialDataJSON(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "KO", `{"financialData":{"financialCurrency":"USD"},"summaryDetail":{"trailingPE":{"raw":24.1,"fmt":"24.10"}}}`)
+	data, err := ticker.FetchFinancialData()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var buf strings.Builder
+	if err := WriteFinancialDataJSON(&buf, data); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	indented, err := data.MarshalJSONIndented()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.String() != string(indented)+"\n" {
+		t.Errorf("Expected WriteFinancialDataJSON to match MarshalJSONIndented, got:\n%s", buf.String())
+	}
+
+	for _, expected := range []string{
+		"\n  \"currency\": \"USD\",",
+		"\"priceToBookRatio\": null",
+		"\"totalRevenue\": null",
+		"\"sections\": [\n    \"financialData\",\n    \"summaryDetail\"\n  ]",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected %q in:\n%s", expected, buf.String())
+		}
+	}
+
+	var decoded FinancialData
+	if err := json.Unmarshal(indented, &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Ratios.PriceToEarningsRatio.Float() != 24.1 || !decoded.HasSection(SectionSummaryDetail) || decoded.HasSection(SectionCashFlow) {
+		t.Errorf("Expected the ratios and sections to round-trip, got %+v with %v", decoded.Ratios, decoded.AvailableSections())
+	}
+}
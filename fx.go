@@ -0,0 +1,265 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fxRateEntry is an exchange rate cached on the client along with the time it was fetched
+type fxRateEntry struct {
+	rate    float64
+	fetched time.Time
+}
+
+// ConvertCurrency converts amount from one currency to another, e.g. ConvertCurrency(100, "EUR", "USD"),
+// at the last rate of Yahoo's FX pair. Amounts in the same currency are returned unchanged without a request,
+// and rates are reused for FXRateTTL. An error matching ErrInvalidParameter is returned for codes that
+// aren't three letters.
+func (c *YFinanceAPI) ConvertCurrency(amount float64, from, to string) (float64, error) {
+	rate, err := c.Client.fxRate(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// minorCurrencyUnits maps the codes Yahoo quotes some listings in, such as "GBp" for the pence of
+// LSE stocks, to their major currency, worth 100 of them
+var minorCurrencyUnits = map[string]string{
+	"GBp": "GBP",
+	"GBX": "GBP",
+	"ZAc": "ZAR",
+	"ZAC": "ZAR",
+	"ILA": "ILS",
+}
+
+// majorCurrency returns the major currency of code along with the number of code units in one of it:
+// 100 for minor-unit codes such as "GBp", 1 for any other code, which is upper-cased
+func majorCurrency(code string) (string, float64) {
+	if major, ok := minorCurrencyUnits[code]; ok {
+		return major, 100
+	}
+	return strings.ToUpper(code), 1
+}
+
+// currencyLabel returns code upper-cased, unless it is a minor-unit code whose case matters
+func currencyLabel(code string) string {
+	if _, ok := minorCurrencyUnits[code]; ok {
+		return code
+	}
+	return strings.ToUpper(code)
+}
+
+// fxRate returns how many units of the "to" currency one unit of the "from" currency buys.
+// It reads the last price of Yahoo's "{FROM}{TO}=X" pair from the chart meta, falling back to
+// the inverse of the "{TO}{FROM}=X" pair when Yahoo only quotes that direction. Minor-unit codes
+// such as "GBp" are converted through their major currency.
+func (c *Client) fxRate(from, to string) (float64, error) {
+	from, fromUnits := majorCurrency(from)
+	to, toUnits := majorCurrency(to)
+	for _, code := range []string{from, to} {
+		if !isCurrencyCode(code) {
+			return 0, fmt.Errorf("invalid currency code %q: %w", code, ErrInvalidParameter)
+		}
+	}
+	rate, err := c.majorRate(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return rate * toUnits / fromUnits, nil
+}
+
+// majorRate returns the exchange rate between two upper-case ISO codes, cached for FXRateTTL
+func (c *Client) majorRate(from, to string) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	if cached, ok := c.fxRates.Load(from + to); ok {
+		if entry := cached.(fxRateEntry); now().Sub(entry.fetched) < FXRateTTL {
+			return entry.rate, nil
+		}
+	}
+
+	rate, err := c.pairRate(from + to + "=X")
+	if err != nil {
+		inverse, inverseErr := c.pairRate(to + from + "=X")
+		if inverseErr != nil {
+			return 0, fmt.Errorf("no exchange rate found from %s to %s: %w", from, to, err)
+		}
+		rate = 1 / inverse
+	}
+
+	c.fxRates.Store(from+to, fxRateEntry{rate: rate, fetched: now()})
+	return rate, nil
+}
+
+// isCurrencyCode reports whether code looks like an ISO 4217 code, e.g. "USD"
+func isCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// pairRate returns the last price of an FX pair such as "EURUSD=X"
+func (c *Client) pairRate(symbol string) (float64, error) {
+	pair := &Ticker{Symbol: symbol, Client: c}
+	historyResponse, err := pair.fetchChartMeta()
+	if err != nil {
+		return 0, err
+	}
+
+	rate := historyResponse.Chart.Result[0].Meta.RegularMarketPrice
+	if rate <= 0 {
+		return 0, fmt.Errorf("exchange rate not available for pair %s: %w", pair.Symbol, ErrNoData)
+	}
+	return rate, nil
+}
+
+// InCurrency returns a copy of the financial data with every statement value, and the earnings and book value
+// per share of the ratios, multiplied by rate and labelled with the target currency. Use it to compare companies
+// reporting in different currencies. The other ratios and the summary are left untouched: they are either
+// unitless or quoted in the trading currency.
+func (d FinancialData) InCurrency(target string, rate float64) FinancialData {
+	converted := d
+	converted.Currency = currencyLabel(target)
+	converted.Ratios.EarningsPerShare = scaledPriceValue(d.Ratios.EarningsPerShare, rate)
+	converted.Ratios.BookValuePerShare = scaledPriceValue(d.Ratios.BookValuePerShare, rate)
+	scalePriceValues(&converted.IncomeStatement, rate)
+	scalePriceValues(&converted.BalanceSheet, rate)
+	scalePriceValues(&converted.CashFlow, rate)
+	return converted
+}
+
+// FetchFinancialDataInCurrency retrieves the financial data converted to the target currency.
+// The FX rate between the reporting currency and the target is fetched from Yahoo's "{FROM}{TO}=X" pair.
+func (t *Ticker) FetchFinancialDataInCurrency(target string) (FinancialData, error) {
+	data, err := t.FetchFinancialData()
+	if err != nil {
+		return FinancialData{}, err
+	}
+
+	if data.Currency == "" {
+		return FinancialData{}, fmt.Errorf("financial currency not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	rate, err := t.Client.fxRate(data.Currency, target)
+	if err != nil {
+		return FinancialData{}, err
+	}
+
+	return data.InCurrency(target, rate), nil
+}
+
+// InCurrency returns a copy of the ticker info with its prices, changes and market cap multiplied by rate
+// and labelled with the target currency. Volumes and percentages are left untouched, and the currency symbol
+// is cleared since it no longer matches.
+func (i YahooTickerInfo) InCurrency(target string, rate float64) YahooTickerInfo {
+	converted := i
+	converted.Currency = currencyLabel(target)
+	converted.CurrencySymbol = ""
+	for _, field := range []**PriceValue{
+		&converted.PreMarketPrice, &converted.PreMarketChange,
+		&converted.PostMarketPrice, &converted.PostMarketChange,
+		&converted.RegularMarketPrice, &converted.RegularMarketChange,
+		&converted.RegularMarketDayHigh, &converted.RegularMarketDayLow,
+		&converted.RegularMarketPreviousClose, &converted.RegularMarketOpen,
+		&converted.StrikePrice, &converted.MarketCap,
+	} {
+		*field = scaledPriceValue(*field, rate)
+	}
+	return converted
+}
+
+// FetchInformationInCurrency retrieves the ticker info with its prices converted to the target currency,
+// e.g. to compare the listings of a cross-listed company. The FX rate is fetched as in ConvertCurrency.
+func (t *Ticker) FetchInformationInCurrency(target string) (YahooTickerInfo, error) {
+	info, err := t.FetchInformation()
+	if err != nil {
+		return YahooTickerInfo{}, err
+	}
+
+	if info.Currency == "" {
+		return YahooTickerInfo{}, fmt.Errorf("currency not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	rate, err := t.Client.fxRate(info.Currency, target)
+	if err != nil {
+		return YahooTickerInfo{}, err
+	}
+
+	return info.InCurrency(target, rate), nil
+}
+
+// scalePriceValues replaces every *PriceValue field of the struct pointed to by v with a scaled copy.
+// The formatted representation is regenerated from the new raw value.
+func scalePriceValues(v interface{}, rate float64) {
+	value := reflect.ValueOf(v).Elem()
+	priceValueType := reflect.TypeOf(&PriceValue{})
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if field.Type() != priceValueType || field.IsNil() {
+			continue
+		}
+
+		field.Set(reflect.ValueOf(scaledPriceValue(field.Interface().(*PriceValue), rate)))
+	}
+}
+
+// scaledPriceValue returns a copy of p multiplied by rate, or nil when p is nil. The formatted representations
+// are regenerated in the style Yahoo used for p: abbreviated with a magnitude suffix such as "2.50T" when
+// Fmt had one, with two decimals otherwise, and LongFmt with thousands separators when p had one.
+func scaledPriceValue(p *PriceValue, rate float64) *PriceValue {
+	if p == nil {
+		return nil
+	}
+	raw := p.Raw * rate
+	scaled := &PriceValue{Raw: raw, Fmt: strconv.FormatFloat(raw, 'f', 2, 64)}
+	if strings.ContainsAny(p.Fmt, "kMBT") {
+		scaled.Fmt = abbreviatedNumber(raw)
+	}
+	if p.LongFmt != "" {
+		scaled.LongFmt = groupedNumber(raw)
+	}
+	return scaled
+}
+
+// abbreviatedNumber formats v with two decimals and the magnitude suffix Yahoo uses, e.g. "2.50T" or "45.60M"
+func abbreviatedNumber(v float64) string {
+	for _, magnitude := range []struct {
+		scale  float64
+		suffix string
+	}{{1e12, "T"}, {1e9, "B"}, {1e6, "M"}, {1e3, "k"}} {
+		if math.Abs(v) >= magnitude.scale {
+			return strconv.FormatFloat(v/magnitude.scale, 'f', 2, 64) + magnitude.suffix
+		}
+	}
+	return strconv.FormatFloat(v, 'f', 2, 64)
+}
+
+// groupedNumber formats v rounded to a whole number with thousands separators, e.g. "2,500,000,000,000"
+func groupedNumber(v float64) string {
+	digits := strconv.FormatFloat(math.Abs(math.Round(v)), 'f', 0, 64)
+	var grouped strings.Builder
+	if v <= -0.5 {
+		grouped.WriteByte('-')
+	}
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	return grouped.String()
+}
@@ -0,0 +1,216 @@
+package yfinance_api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFinancialDataInCurrency tests converting statement values with an FX rate
+func TestFinancialDataInCurrency(t *testing.T) {
+	revenue := &PriceValue{Raw: 1000, Fmt: "1k"}
+	data := FinancialData{
+		Currency:        "JPY",
+		Ratios:          FinancialRatios{PriceToEarningsRatio: &PriceValue{Raw: 12}, EarningsPerShare: &PriceValue{Raw: 200, Fmt: "200.00"}},
+		IncomeStatement: IncomeStatement{TotalRevenue: revenue},
+		CashFlow:        CashFlow{FreeCashFlow: &PriceValue{Raw: -200}},
+	}
+
+	converted := data.InCurrency("usd", 0.0065)
+
+	if converted.Currency != "USD" {
+		t.Errorf("Expected currency USD, got %s", converted.Currency)
+	}
+	if converted.IncomeStatement.TotalRevenue.Raw != 6.5 || converted.IncomeStatement.TotalRevenue.Fmt != "6.50" {
+		t.Errorf("Unexpected converted revenue: %+v", converted.IncomeStatement.TotalRevenue)
+	}
+	if converted.CashFlow.FreeCashFlow.Raw != -1.3 {
+		t.Errorf("Expected converted free cash flow -1.3, got %f", converted.CashFlow.FreeCashFlow.Raw)
+	}
+	if converted.IncomeStatement.NetIncome != nil {
+		t.Error("Expected missing values to stay nil")
+	}
+	if converted.Ratios.PriceToEarningsRatio.Raw != 12 {
+		t.Error("Expected unitless ratios to be left untouched")
+	}
+	if converted.Ratios.EarningsPerShare.Raw != 1.3 || converted.Ratios.BookValuePerShare != nil {
+		t.Errorf("Expected the earnings per share to be converted, got %+v", converted.Ratios.EarningsPerShare)
+	}
+
+	// The original data must not be modified
+	if revenue.Raw != 1000 || data.Currency != "JPY" {
+		t.Error("InCurrency modified the original data")
+	}
+}
+
+// TestFXRate tests reading an exchange rate from the pair's chart meta
+func TestFXRate(t *testing.T) {
+	requests := newChartServer(t, `{"chart":{"result":[{"meta":{"symbol":"EURUSD=X","regularMarketPrice":1.085}}],"error":null}}`)
+	client := newTestClient()
+
+	rate, err := client.fxRate("eur", "usd")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rate != 1.085 {
+		t.Errorf("Expected rate 1.085, got %f", rate)
+	}
+
+	rate, err = client.fxRate("USD", "usd")
+	if err != nil || rate != 1 {
+		t.Errorf("Expected identity rate 1, got %f (%v)", rate, err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected the identity rate to skip the network, got %d requests", requests.Load())
+	}
+}
+
+// TestFXRateMinorUnits tests that prices quoted in pence are converted through pounds, not as pounds
+func TestFXRateMinorUnits(t *testing.T) {
+	// The server quotes 1.25 for any pair, USDGBP=X included
+	requests := newChartServer(t, `{"chart":{"result":[{"meta":{"symbol":"GBPUSD=X","regularMarketPrice":1.25}}],"error":null}}`)
+	client := newTestClient()
+
+	testCases := []struct {
+		from, to string
+		expected float64
+	}{
+		{"GBp", "USD", 0.0125},
+		{"USD", "GBp", 125},
+		{"GBp", "GBP", 0.01},
+		{"GBP", "GBp", 100},
+		{"GBP", "USD", 1.25},
+	}
+	for _, tc := range testCases {
+		rate, err := client.fxRate(tc.from, tc.to)
+		if err != nil || math.Abs(rate-tc.expected) > 1e-9 {
+			t.Errorf("%s to %s: expected %v, got %v (%v)", tc.from, tc.to, tc.expected, rate, err)
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected GBPUSD=X and USDGBP=X to be fetched once each, got %d requests", got)
+	}
+
+	info := YahooTickerInfo{Currency: "GBp", RegularMarketPrice: &PriceValue{Raw: 2500}}
+	rate, _ := client.fxRate(info.Currency, "usd")
+	if converted := info.InCurrency("usd", rate); math.Abs(converted.RegularMarketPrice.Raw-31.25) > 1e-9 || converted.Currency != "USD" {
+		t.Errorf("Expected 2500 pence to be 31.25 USD, got %+v %s", converted.RegularMarketPrice, converted.Currency)
+	}
+}
+
+// TestConvertCurrency tests converting through the direct pair and falling back to the inverse pair
+func TestConvertCurrency(t *testing.T) {
+	rates := map[string]float64{"EURUSD=X": 1.085, "USDJPY=X": 150}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbol := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		rate, ok := rates[symbol]
+		if !ok {
+			fmt.Fprint(w, `{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found, symbol may be delisted"}}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"chart":{"result":[{"meta":{"symbol":%q,"regularMarketPrice":%v}}],"error":null}}`, symbol, rate)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	api := &YFinanceAPI{Client: newTestClient()}
+	testCases := []struct {
+		from, to string
+		expected float64
+	}{
+		{"EUR", "USD", 108.5},
+		{"jpy", "usd", 100.0 / 150},
+		{"USD", "USD", 100},
+	}
+	for _, tc := range testCases {
+		converted, err := api.ConvertCurrency(100, tc.from, tc.to)
+		if err != nil {
+			t.Errorf("%s to %s: unexpected error: %v", tc.from, tc.to, err)
+			continue
+		}
+		if math.Abs(converted-tc.expected) > 1e-9 {
+			t.Errorf("%s to %s: expected %v, got %v", tc.from, tc.to, tc.expected, converted)
+		}
+	}
+
+	if _, err := api.ConvertCurrency(100, "XXX", "USD"); err == nil {
+		t.Error("Expected an error when neither pair is quoted")
+	}
+	if _, err := api.ConvertCurrency(100, "EURO", "USD"); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter for an invalid code, got %v", err)
+	}
+}
+
+// TestFXRateCache tests that rates are reused until FXRateTTL has elapsed
+func TestFXRateCache(t *testing.T) {
+	requests := newChartServer(t, `{"chart":{"result":[{"meta":{"symbol":"EURUSD=X","regularMarketPrice":1.085}}],"error":null}}`)
+	current := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	setNow(t, current)
+	client := newTestClient()
+
+	for i := 0; i < 3; i++ {
+		if _, err := client.fxRate("EUR", "USD"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected the rate to be fetched once, got %d requests", requests.Load())
+	}
+
+	setNow(t, current.Add(FXRateTTL))
+	if _, err := client.fxRate("EUR", "USD"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected an expired rate to be fetched again, got %d requests", requests.Load())
+	}
+}
+
+// TestTickerInfoInCurrency tests converting prices while leaving volumes and percentages untouched
+func TestTickerInfoInCurrency(t *testing.T) {
+	info := YahooTickerInfo{
+		Currency:                   "EUR",
+		CurrencySymbol:             "€",
+		RegularMarketPrice:         &PriceValue{Raw: 100, Fmt: "100.00"},
+		RegularMarketChangePercent: &PriceValue{Raw: 0.02},
+		RegularMarketVolume:        &PriceValue{Raw: 5000},
+		MarketCap:                  &PriceValue{Raw: 2.5e12, Fmt: "2.5T", LongFmt: "2,500,000,000,000"},
+	}
+
+	converted := info.InCurrency("usd", 1.1)
+
+	if converted.Currency != "USD" || converted.CurrencySymbol != "" {
+		t.Errorf("Unexpected currency %q %q", converted.Currency, converted.CurrencySymbol)
+	}
+	if math.Abs(converted.RegularMarketPrice.Raw-110) > 1e-9 || math.Abs(converted.MarketCap.Raw-2.75e12) > 1 {
+		t.Errorf("Unexpected converted prices: %+v %+v", converted.RegularMarketPrice, converted.MarketCap)
+	}
+	if converted.MarketCap.Fmt != "2.75T" || converted.MarketCap.LongFmt != "2,750,000,000,000" || converted.RegularMarketPrice.Fmt != "110.00" {
+		t.Errorf("Expected Yahoo's formatting to be kept, got %+v %+v", converted.MarketCap, converted.RegularMarketPrice)
+	}
+	if converted.RegularMarketVolume.Raw != 5000 || converted.RegularMarketChangePercent.Raw != 0.02 {
+		t.Error("Expected volumes and percentages to be left untouched")
+	}
+	if converted.RegularMarketOpen != nil {
+		t.Error("Expected missing values to stay nil")
+	}
+	if info.RegularMarketPrice.Raw != 100 {
+		t.Error("InCurrency modified the original info")
+	}
+}
+
+// TestScaledPriceValueFormat tests regenerating abbreviated and grouped formats, including negative values
+func TestScaledPriceValueFormat(t *testing.T) {
+	scaled := scaledPriceValue(&PriceValue{Raw: -1234567, Fmt: "-1.23M", LongFmt: "-1,234,567"}, 1)
+	if scaled.Fmt != "-1.23M" || scaled.LongFmt != "-1,234,567" {
+		t.Errorf("Unexpected formats %q and %q", scaled.Fmt, scaled.LongFmt)
+	}
+	if scaled := scaledPriceValue(&PriceValue{Raw: 999, Fmt: "999k", LongFmt: "999"}, 1); scaled.Fmt != "999.00" || scaled.LongFmt != "999" {
+		t.Errorf("Expected no suffix or separator below a thousand, got %q and %q", scaled.Fmt, scaled.LongFmt)
+	}
+}
@@ -0,0 +1,240 @@
+package yfinance_api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Kinds of insider transactions, as set in InsiderTransaction.Type
+const (
+	InsiderBuy   = "Buy"
+	InsiderSell  = "Sell"
+	InsiderOther = "Other" // Awards, option exercises, gifts...
+)
+
+// MajorHolders summarizes who owns the shares of a company, from the majorHoldersBreakdown module.
+// Percentages are fractions, e.g. 0.61 for 61%.
+type MajorHolders struct {
+	InsidersPercentHeld          *PriceValue `json:"insidersPercentHeld"`
+	InstitutionsPercentHeld      *PriceValue `json:"institutionsPercentHeld"`
+	InstitutionsFloatPercentHeld *PriceValue `json:"institutionsFloatPercentHeld"` // Share of the float held by institutions
+	InstitutionsCount            *PriceValue `json:"institutionsCount"`
+}
+
+// Holder is an institution or fund holding shares of a company, as of its last filing
+type Holder struct {
+	Organization string      `json:"organization"`
+	Shares       *PriceValue `json:"shares"`
+	DateReported time.Time   `json:"dateReported"`
+	PctHeld      *PriceValue `json:"pctHeld"` // Fraction of shares outstanding
+	Value        *PriceValue `json:"value"`   // Market value of the position
+}
+
+// InsiderTransaction is a trade of company shares by one of its insiders, as filed with the SEC
+type InsiderTransaction struct {
+	Name      string      `json:"name"`
+	Relation  string      `json:"relation"` // e.g. "Director" or "Chief Executive Officer"
+	Text      string      `json:"text"`     // Yahoo's description, e.g. "Sale at price 170.00 per share."
+	Type      string      `json:"type"`     // InsiderBuy, InsiderSell or InsiderOther
+	Shares    *PriceValue `json:"shares"`
+	Value     *PriceValue `json:"value"` // Value of the trade, missing for awards and gifts
+	StartDate time.Time   `json:"startDate"`
+}
+
+// InsiderHolder is an insider holding shares of the company, with their latest transaction
+type InsiderHolder struct {
+	Name                 string      `json:"name"`
+	Relation             string      `json:"relation"`
+	LatestTransaction    string      `json:"latestTransaction"` // e.g. "Sale" or "Stock Award(Grant)"
+	LatestTransDate      time.Time   `json:"latestTransDate"`
+	PositionDirect       *PriceValue `json:"positionDirect"` // Shares held directly, nil when undisclosed
+	PositionDirectDate   time.Time   `json:"positionDirectDate"`
+	PositionIndirect     *PriceValue `json:"positionIndirect"` // Shares held through trusts or family members
+	PositionIndirectDate time.Time   `json:"positionIndirectDate"`
+}
+
+// yahooOwnershipList is the layout shared by the institutionOwnership and fundOwnership modules
+type yahooOwnershipList struct {
+	OwnershipList []struct {
+		ReportDate   *PriceValue `json:"reportDate"`
+		Organization string      `json:"organization"`
+		PctHeld      *PriceValue `json:"pctHeld"`
+		Position     *PriceValue `json:"position"`
+		Value        *PriceValue `json:"value"`
+	} `json:"ownershipList"`
+}
+
+// FetchMajorHolders retrieves the percentage of shares held by insiders and institutions,
+// and the number of institutions holding shares, from the majorHoldersBreakdown module.
+// ErrNoData is returned when Yahoo has no ownership data for the ticker.
+func (t *Ticker) FetchMajorHolders() (MajorHolders, error) {
+	result, err := t.fetchQuoteSummary("majorHoldersBreakdown")
+	if err != nil {
+		return MajorHolders{}, err
+	}
+
+	var summary struct {
+		MajorHoldersBreakdown *MajorHolders `json:"majorHoldersBreakdown"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return MajorHolders{}, fmt.Errorf("failed to decode major holders JSON response: %v", err)
+	}
+
+	if summary.MajorHoldersBreakdown == nil {
+		return MajorHolders{}, fmt.Errorf("no major holders found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return *summary.MajorHoldersBreakdown, nil
+}
+
+// FetchInstitutionalHolders retrieves the top institutional holders of the ticker, largest first,
+// from the institutionOwnership module. ErrNoData is returned when Yahoo lists none.
+func (t *Ticker) FetchInstitutionalHolders() ([]Holder, error) {
+	return t.fetchHolders("institutionOwnership")
+}
+
+// FetchFundHolders retrieves the top mutual fund holders of the ticker, largest first,
+// from the fundOwnership module. ErrNoData is returned when Yahoo lists none.
+func (t *Ticker) FetchFundHolders() ([]Holder, error) {
+	return t.fetchHolders("fundOwnership")
+}
+
+// fetchHolders requests one of the ownership list modules and converts its entries
+func (t *Ticker) fetchHolders(module string) ([]Holder, error) {
+	result, err := t.fetchQuoteSummary(module)
+	if err != nil {
+		return nil, err
+	}
+
+	var summary map[string]json.RawMessage
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode %s JSON response: %v", module, err)
+	}
+
+	var ownership yahooOwnershipList
+	if raw, ok := summary[module]; ok {
+		if err := json.Unmarshal(raw, &ownership); err != nil {
+			return nil, fmt.Errorf("failed to decode %s JSON response: %v", module, err)
+		}
+	}
+	if len(ownership.OwnershipList) == 0 {
+		return nil, fmt.Errorf("no holders found in %s for symbol %s: %w", module, t.Symbol, ErrNoData)
+	}
+
+	holders := make([]Holder, 0, len(ownership.OwnershipList))
+	for _, entry := range ownership.OwnershipList {
+		holders = append(holders, Holder{
+			Organization: entry.Organization,
+			Shares:       entry.Position,
+			DateReported: entry.ReportDate.AsTime(),
+			PctHeld:      entry.PctHeld,
+			Value:        entry.Value,
+		})
+	}
+	return holders, nil
+}
+
+// FetchInsiderTransactions retrieves the recent insider buys and sells of the ticker, latest first,
+// from the insiderTransactions module. ErrNoData is returned when Yahoo lists none.
+func (t *Ticker) FetchInsiderTransactions() ([]InsiderTransaction, error) {
+	result, err := t.fetchQuoteSummary("insiderTransactions")
+	if err != nil {
+		return nil, err
+	}
+
+	var summary struct {
+		InsiderTransactions struct {
+			Transactions []struct {
+				FilerName       string      `json:"filerName"`
+				FilerRelation   string      `json:"filerRelation"`
+				TransactionText string      `json:"transactionText"`
+				Shares          *PriceValue `json:"shares"`
+				Value           *PriceValue `json:"value"`
+				StartDate       *PriceValue `json:"startDate"`
+			} `json:"transactions"`
+		} `json:"insiderTransactions"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode insider transactions JSON response: %v", err)
+	}
+
+	if len(summary.InsiderTransactions.Transactions) == 0 {
+		return nil, fmt.Errorf("no insider transactions found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	transactions := make([]InsiderTransaction, 0, len(summary.InsiderTransactions.Transactions))
+	for _, entry := range summary.InsiderTransactions.Transactions {
+		transactions = append(transactions, InsiderTransaction{
+			Name:      entry.FilerName,
+			Relation:  entry.FilerRelation,
+			Text:      entry.TransactionText,
+			Type:      insiderTransactionType(entry.TransactionText),
+			Shares:    entry.Shares,
+			Value:     entry.Value,
+			StartDate: entry.StartDate.AsTime(),
+		})
+	}
+	return transactions, nil
+}
+
+// insiderTransactionType tells buys from sells using Yahoo's description of the transaction,
+// which starts with "Purchase" or "Sale" for trades on the market
+func insiderTransactionType(text string) string {
+	text = strings.ToLower(strings.TrimSpace(text))
+	switch {
+	case strings.HasPrefix(text, "purchase"), strings.HasPrefix(text, "buy"):
+		return InsiderBuy
+	case strings.HasPrefix(text, "sale"), strings.HasPrefix(text, "sell"):
+		return InsiderSell
+	default:
+		return InsiderOther
+	}
+}
+
+// FetchInsiderHolders retrieves the insiders holding shares of the ticker, with their direct and indirect
+// positions and latest transaction, from the insiderHolders module. ErrNoData is returned when Yahoo lists none.
+func (t *Ticker) FetchInsiderHolders() ([]InsiderHolder, error) {
+	result, err := t.fetchQuoteSummary("insiderHolders")
+	if err != nil {
+		return nil, err
+	}
+
+	var summary struct {
+		InsiderHolders struct {
+			Holders []struct {
+				Name                   string      `json:"name"`
+				Relation               string      `json:"relation"`
+				TransactionDescription string      `json:"transactionDescription"`
+				LatestTransDate        *PriceValue `json:"latestTransDate"`
+				PositionDirect         *PriceValue `json:"positionDirect"`
+				PositionDirectDate     *PriceValue `json:"positionDirectDate"`
+				PositionIndirect       *PriceValue `json:"positionIndirect"`
+				PositionIndirectDate   *PriceValue `json:"positionIndirectDate"`
+			} `json:"holders"`
+		} `json:"insiderHolders"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode insider holders JSON response: %v", err)
+	}
+
+	if len(summary.InsiderHolders.Holders) == 0 {
+		return nil, fmt.Errorf("no insider holders found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	holders := make([]InsiderHolder, 0, len(summary.InsiderHolders.Holders))
+	for _, entry := range summary.InsiderHolders.Holders {
+		holders = append(holders, InsiderHolder{
+			Name:                 entry.Name,
+			Relation:             entry.Relation,
+			LatestTransaction:    entry.TransactionDescription,
+			LatestTransDate:      entry.LatestTransDate.AsTime(),
+			PositionDirect:       entry.PositionDirect,
+			PositionDirectDate:   entry.PositionDirectDate.AsTime(),
+			PositionIndirect:     entry.PositionIndirect,
+			PositionIndirectDate: entry.PositionIndirectDate.AsTime(),
+		})
+	}
+	return holders, nil
+}
@@ -0,0 +1,135 @@
+package yfinance_api
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestFetchMajorHolders tests parsing the majorHoldersBreakdown module
+func TestFetchMajorHolders(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"majorHoldersBreakdown":{"maxAge":1,
+		"insidersPercentHeld":{"raw":0.0017,"fmt":"0.17%"},"institutionsPercentHeld":{"raw":0.6145,"fmt":"61.45%"},
+		"institutionsFloatPercentHeld":{"raw":0.6156,"fmt":"61.56%"},"institutionsCount":{"raw":6379,"fmt":"6.38k"}}}`)
+
+	holders, err := ticker.FetchMajorHolders()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if holders.InsidersPercentHeld == nil || holders.InsidersPercentHeld.Raw != 0.0017 {
+		t.Errorf("Unexpected insiders percentage: %+v", holders.InsidersPercentHeld)
+	}
+	if holders.InstitutionsFloatPercentHeld == nil || holders.InstitutionsCount == nil || holders.InstitutionsCount.Raw != 6379 {
+		t.Errorf("Unexpected institution figures: %+v", holders)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "TINY", `{}`)
+	if _, err := ticker.FetchMajorHolders(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without the module, got %v", err)
+	}
+}
+
+// TestFetchInstitutionalHolders tests converting the ownership list of the institutionOwnership module
+func TestFetchInstitutionalHolders(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"institutionOwnership":{"maxAge":1,"ownershipList":[
+		{"reportDate":{"raw":1711843200,"fmt":"2024-03-31"},"organization":"Vanguard Group Inc","pctHeld":{"raw":0.0874},
+			"position":{"raw":1349479013},"value":{"raw":231408659649}},
+		{"reportDate":{"raw":1711843200,"fmt":"2024-03-31"},"organization":"Blackrock Inc.","pctHeld":{"raw":0.0663},
+			"position":{"raw":1023834000},"value":{"raw":175564675321}}]}}`)
+
+	holders, err := ticker.FetchInstitutionalHolders()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(holders) != 2 {
+		t.Fatalf("Expected 2 holders, got %d", len(holders))
+	}
+	top := holders[0]
+	if top.Organization != "Vanguard Group Inc" || top.Shares.Raw != 1349479013 || top.PctHeld.Raw != 0.0874 {
+		t.Errorf("Unexpected top holder: %+v", top)
+	}
+	if !top.DateReported.Equal(time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected report date %s", top.DateReported)
+	}
+
+	if _, err := ticker.FetchFundHolders(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without fundOwnership, got %v", err)
+	}
+}
+
+// TestFetchInsiderTransactions tests converting the insiderTransactions module and telling buys from sells
+func TestFetchInsiderTransactions(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"insiderTransactions":{"maxAge":1,"transactions":[
+		{"shares":{"raw":100000,"fmt":"100k"},"value":{"raw":17000000,"fmt":"17M"},"filerUrl":"",
+			"transactionText":"Sale at price 170.00 per share.","filerName":"LEVINSON ARTHUR D","filerRelation":"Director",
+			"moneyText":"","startDate":{"raw":1700006400,"fmt":"2023-11-15"},"ownership":"D"},
+		{"shares":{"raw":2500},"value":{"raw":400000},"transactionText":"Purchase at price 160.00 per share.",
+			"filerName":"DOE JANE","filerRelation":"Officer","startDate":{"raw":1699920000,"fmt":"2023-11-14"},"ownership":"I"},
+		{"shares":{"raw":511000},"transactionText":"","filerName":"COOK TIMOTHY D",
+			"filerRelation":"Chief Executive Officer","startDate":{"raw":1696118400,"fmt":"2023-10-01"},"ownership":"D"}]}}`)
+
+	transactions, err := ticker.FetchInsiderTransactions()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(transactions) != 3 {
+		t.Fatalf("Expected 3 transactions, got %d", len(transactions))
+	}
+	sale := transactions[0]
+	if sale.Name != "LEVINSON ARTHUR D" || sale.Relation != "Director" || sale.Shares.Raw != 100000 || sale.Value.Raw != 17000000 {
+		t.Errorf("Unexpected sale: %+v", sale)
+	}
+	if !sale.StartDate.Equal(time.Date(2023, 11, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected start date %s", sale.StartDate)
+	}
+	for i, expected := range []string{InsiderSell, InsiderBuy, InsiderOther} {
+		if transactions[i].Type != expected {
+			t.Errorf("Expected transaction %d to be a %s, got %q", i, expected, transactions[i].Type)
+		}
+	}
+	if transactions[2].Value != nil {
+		t.Errorf("Expected no value for an award, got %+v", transactions[2].Value)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "TINY", `{"insiderTransactions":{"transactions":[]}}`)
+	if _, err := ticker.FetchInsiderTransactions(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without transactions, got %v", err)
+	}
+}
+
+// TestFetchInsiderHolders tests converting the holders of the insiderHolders module
+func TestFetchInsiderHolders(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"insiderHolders":{"maxAge":1,"holders":[
+		{"maxAge":1,"name":"COOK TIMOTHY D","relation":"Chief Executive Officer","url":"",
+			"transactionDescription":"Sale","latestTransDate":{"raw":1696118400,"fmt":"2023-10-01"},
+			"positionDirect":{"raw":3280180,"fmt":"3.28M"},"positionDirectDate":{"raw":1696118400,"fmt":"2023-10-01"}},
+		{"maxAge":1,"name":"LEVINSON ARTHUR D","relation":"Director","transactionDescription":"Stock Gift",
+			"latestTransDate":{"raw":1700006400},"positionIndirect":{"raw":1000000},"positionIndirectDate":{"raw":1700006400}}]}}`)
+
+	holders, err := ticker.FetchInsiderHolders()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(holders) != 2 {
+		t.Fatalf("Expected 2 holders, got %d", len(holders))
+	}
+	ceo := holders[0]
+	if ceo.Name != "COOK TIMOTHY D" || ceo.Relation != "Chief Executive Officer" || ceo.LatestTransaction != "Sale" {
+		t.Errorf("Unexpected holder: %+v", ceo)
+	}
+	if ceo.PositionDirect == nil || ceo.PositionDirect.Raw != 3280180 || !ceo.PositionDirectDate.Equal(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected direct position: %+v", ceo)
+	}
+	if holders[1].PositionDirect != nil || !holders[1].PositionDirectDate.IsZero() || holders[1].PositionIndirect.Raw != 1000000 {
+		t.Errorf("Expected only an indirect position, got %+v", holders[1])
+	}
+
+	ticker = newQuoteSummaryTicker(t, "TINY", `{}`)
+	if _, err := ticker.FetchInsiderHolders(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without the module, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package yfinance_api
+
+// SMA returns the simple moving average of the closing prices over period candles, aligned with candles.
+// The first period-1 values are nil, as the window isn't full yet, and so is every value when period
+// is not positive or exceeds the number of candles. Candles without a close, such as a halted bar,
+// get nil and are left out of the windows.
+func SMA(candles []Candle, period int) []*float64 {
+	return indicator(candles, period, period, func(closes []float64) []float64 {
+		values := make([]float64, len(closes))
+		sum := 0.0
+		for i, price := range closes {
+			sum += price
+			if i >= period {
+				sum -= closes[i-period]
+			}
+			values[i] = sum / float64(period)
+		}
+		return values
+	})
+}
+
+// EMA returns the exponential moving average of the closing prices over period candles, aligned with candles,
+// with a smoothing factor of 2/(period+1). It is seeded with the SMA of the first period closes, so the
+// warm-up values, and candles without a close, are nil as for SMA.
+func EMA(candles []Candle, period int) []*float64 {
+	return indicator(candles, period, period, func(closes []float64) []float64 {
+		values := make([]float64, len(closes))
+		alpha := 2 / float64(period+1)
+		sum := 0.0
+		for i, price := range closes {
+			if i < period {
+				// Running mean of the closes so far, which is the SMA seed at period-1
+				sum += price
+				values[i] = sum / float64(i+1)
+				continue
+			}
+			values[i] = alpha*price + (1-alpha)*values[i-1]
+		}
+		return values
+	})
+}
+
+// RSI returns Wilder's relative strength index of the closing prices over period candles, from 0 to 100,
+// aligned with candles. It needs period price changes, so the first period values are nil, as are candles
+// without a close. A window without any loss gives 100, and one without any change gives 50.
+func RSI(candles []Candle, period int) []*float64 {
+	return indicator(candles, period, period+1, func(closes []float64) []float64 {
+		values := make([]float64, len(closes))
+		var averageGain, averageLoss float64
+		for i := 1; i < len(closes); i++ {
+			gain, loss := 0.0, 0.0
+			if change := closes[i] - closes[i-1]; change > 0 {
+				gain = change
+			} else {
+				loss = -change
+			}
+
+			if i <= period {
+				averageGain += gain / float64(period)
+				averageLoss += loss / float64(period)
+			} else {
+				averageGain = (averageGain*float64(period-1) + gain) / float64(period)
+				averageLoss = (averageLoss*float64(period-1) + loss) / float64(period)
+			}
+
+			switch {
+			case averageLoss == 0 && averageGain == 0:
+				values[i] = 50
+			case averageLoss == 0:
+				values[i] = 100
+			default:
+				values[i] = 100 - 100/(1+averageGain/averageLoss)
+			}
+		}
+		return values
+	})
+}
+
+// indicator runs compute over the available closes of candles and aligns its results with candles,
+// leaving nil the first warmUp-1 results, the candles without a close, and everything when period
+// is not positive or there are fewer than warmUp closes
+func indicator(candles []Candle, period, warmUp int, compute func(closes []float64) []float64) []*float64 {
+	aligned := make([]*float64, len(candles))
+	if period < 1 {
+		return aligned
+	}
+
+	closes := make([]float64, 0, len(candles))
+	indexes := make([]int, 0, len(candles))
+	for i, candle := range candles {
+		if candle.Close != nil {
+			closes = append(closes, *candle.Close)
+			indexes = append(indexes, i)
+		}
+	}
+	if len(closes) < warmUp {
+		return aligned
+	}
+
+	for i, value := range compute(closes) {
+		if i >= warmUp-1 {
+			value := value
+			aligned[indexes[i]] = &value
+		}
+	}
+	return aligned
+}
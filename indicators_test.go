@@ -0,0 +1,63 @@
+package yfinance_api
+
+import (
+	"math"
+	"testing"
+)
+
+// closeCandles builds candles with the given closes, nil standing for a candle without a close
+func closeCandles(closes ...*float64) []Candle {
+	candles := make([]Candle, len(closes))
+	for i, price := range closes {
+		candles[i] = Candle{Close: price}
+	}
+	return candles
+}
+
+// assertSeries compares an indicator series to the expected values, nil meaning no value
+func assertSeries(t *testing.T, name string, got []*float64, expected []*float64) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("%s: expected %d values, got %d", name, len(expected), len(got))
+	}
+	for i := range expected {
+		switch {
+		case expected[i] == nil && got[i] != nil:
+			t.Errorf("%s[%d]: expected nil, got %v", name, i, *got[i])
+		case expected[i] != nil && got[i] == nil:
+			t.Errorf("%s[%d]: expected %v, got nil", name, i, *expected[i])
+		case expected[i] != nil && math.Abs(*got[i]-*expected[i]) > 1e-9:
+			t.Errorf("%s[%d]: expected %v, got %v", name, i, *expected[i], *got[i])
+		}
+	}
+}
+
+// TestSMAAndEMA tests the moving averages, their warm-up and the candles without a close
+func TestSMAAndEMA(t *testing.T) {
+	candles := closeCandles(floatPtr(1), floatPtr(2), nil, floatPtr(3), floatPtr(4), floatPtr(5))
+
+	assertSeries(t, "SMA", SMA(candles, 3), []*float64{nil, nil, nil, floatPtr(2), floatPtr(3), floatPtr(4)})
+	assertSeries(t, "EMA", EMA(candles, 3), []*float64{nil, nil, nil, floatPtr(2), floatPtr(3), floatPtr(4)})
+	assertSeries(t, "SMA(1)", SMA(candles, 1), []*float64{floatPtr(1), floatPtr(2), nil, floatPtr(3), floatPtr(4), floatPtr(5)})
+
+	for _, period := range []int{0, -1, 6, 100} {
+		for name, series := range map[string][]*float64{"SMA": SMA(candles, period), "EMA": EMA(candles, period), "RSI": RSI(candles, period)} {
+			assertSeries(t, name, series, make([]*float64, len(candles)))
+		}
+	}
+	if got := SMA(nil, 3); len(got) != 0 {
+		t.Errorf("Expected an empty series for no candles, got %v", got)
+	}
+}
+
+// TestRSI tests Wilder's smoothing and the edge cases without losses or changes
+func TestRSI(t *testing.T) {
+	candles := closeCandles(floatPtr(1), floatPtr(2), floatPtr(3), floatPtr(2))
+	assertSeries(t, "RSI", RSI(candles, 2), []*float64{nil, nil, floatPtr(100), floatPtr(50)})
+
+	flat := closeCandles(floatPtr(5), floatPtr(5), floatPtr(5))
+	assertSeries(t, "RSI", RSI(flat, 2), []*float64{nil, nil, floatPtr(50)})
+
+	// RSI needs period changes, so period closes aren't enough
+	assertSeries(t, "RSI", RSI(flat, 3), []*float64{nil, nil, nil})
+}
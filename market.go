@@ -0,0 +1,111 @@
+package yfinance_api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// MarketSummaryItem represents one index or benchmark of a region's market summary
+type MarketSummaryItem struct {
+	Symbol                     string      `json:"symbol"`
+	ShortName                  string      `json:"shortName"`
+	Exchange                   string      `json:"exchange"`
+	FullExchangeName           string      `json:"fullExchangeName"`
+	QuoteType                  string      `json:"quoteType"`
+	MarketState                string      `json:"marketState"`
+	RegularMarketPrice         *PriceValue `json:"regularMarketPrice"`
+	RegularMarketChange        *PriceValue `json:"regularMarketChange"`
+	RegularMarketChangePercent *PriceValue `json:"regularMarketChangePercent"`
+	RegularMarketPreviousClose *PriceValue `json:"regularMarketPreviousClose"`
+	RegularMarketTime          *PriceValue `json:"regularMarketTime"`
+}
+
+// YahooMarketSummaryResponse represents the response from Yahoo Finance market summary API
+type YahooMarketSummaryResponse struct {
+	MarketSummaryResponse struct {
+		Result []MarketSummaryItem `json:"result"`
+		Error  interface{}         `json:"error"`
+	} `json:"marketSummaryResponse"`
+}
+
+// FetchMarketSummary retrieves the main indices and benchmarks of a region (e.g. "US", "GB", "HK").
+// The region defaults to "US" when empty.
+func (c *YFinanceAPI) FetchMarketSummary(region string) ([]MarketSummaryItem, error) {
+	region = marketRegion(region)
+
+	params := url.Values{}
+	params.Add("region", region)
+	params.Add("lang", "en-US")
+
+	endpoint := fmt.Sprintf("%s/v6/finance/quote/marketSummary", c.Client.baseURL())
+
+	resp, err := c.Client.Get(endpoint, params)
+	if err != nil {
+		c.Client.log().Error("Failed to get market summary", "err", err)
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			c.Client.log().Error("Failed to close response body", "err", err)
+		}
+	}(resp.Body)
+
+	var summaryResponse YahooMarketSummaryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&summaryResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode market summary JSON response: %v", err)
+	}
+
+	if len(summaryResponse.MarketSummaryResponse.Result) == 0 {
+		if yahooErr := newYahooError(summaryResponse.MarketSummaryResponse.Error); yahooErr != nil {
+			return nil, fmt.Errorf("failed to get market summary for region %s: %w", region, yahooErr)
+		}
+		return nil, fmt.Errorf("no market summary found for region %s: %w", region, ErrNoData)
+	}
+
+	return summaryResponse.MarketSummaryResponse.Result, nil
+}
+
+// FetchGlobalMarketSummary retrieves the market summaries of several regions concurrently, keyed by region
+// upper-cased as in FetchMarketSummary, so that "us", "US" and "" are fetched once under "US".
+// At most DefaultConcurrency requests run at once. Regions that fail are left out of the result and
+// reported through a *BatchError, so the summaries that did succeed are still usable.
+func (c *YFinanceAPI) FetchGlobalMarketSummary(regions []string) (map[string][]MarketSummaryItem, error) {
+	// Bootstrap the crumb once up front rather than from every goroutine
+	c.Client.getCrumb(context.Background())
+
+	normalized := make([]string, len(regions))
+	for i, region := range regions {
+		normalized[i] = marketRegion(region)
+	}
+
+	var mu sync.Mutex
+	summaries := make(map[string][]MarketSummaryItem, len(regions))
+
+	err := forEachConcurrent(normalized, DefaultConcurrency, func(region string) error {
+		items, err := c.FetchMarketSummary(region)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		summaries[region] = items
+		mu.Unlock()
+		return nil
+	})
+
+	return summaries, err
+}
+
+// marketRegion normalizes a market summary region: upper-cased, and "US" when empty
+func marketRegion(region string) string {
+	if region == "" {
+		return "US"
+	}
+	return strings.ToUpper(region)
+}
@@ -0,0 +1,112 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarketStatus is the trading session a ticker's exchange is in, as reported in YahooTickerInfo.MarketState
+type MarketStatus string
+
+// Market states reported by Yahoo. PREPRE and POSTPOST are the overnight hours around the extended
+// sessions, during which nothing trades.
+const (
+	MarketStatusUnknown  MarketStatus = ""
+	MarketStatusPre      MarketStatus = "PRE"
+	MarketStatusRegular  MarketStatus = "REGULAR"
+	MarketStatusPost     MarketStatus = "POST"
+	MarketStatusClosed   MarketStatus = "CLOSED"
+	MarketStatusPrePre   MarketStatus = "PREPRE"
+	MarketStatusPostPost MarketStatus = "POSTPOST"
+)
+
+// MarketStatus returns the typed market state of the ticker, or MarketStatusUnknown when Yahoo sent none
+// or a state this package doesn't know
+func (i YahooTickerInfo) MarketStatus() MarketStatus {
+	return parseMarketStatus(i.MarketState)
+}
+
+// MarketStatus returns the typed market state of the quote, as YahooTickerInfo.MarketStatus does
+func (f FastInfo) MarketStatus() MarketStatus {
+	return parseMarketStatus(f.MarketState)
+}
+
+// parseMarketStatus maps a raw market state to its MarketStatus, MarketStatusUnknown when not recognized
+func parseMarketStatus(state string) MarketStatus {
+	switch status := MarketStatus(strings.ToUpper(state)); status {
+	case MarketStatusPre, MarketStatusRegular, MarketStatusPost, MarketStatusClosed, MarketStatusPrePre, MarketStatusPostPost:
+		return status
+	default:
+		return MarketStatusUnknown
+	}
+}
+
+// IsMarketOpen reports whether the regular session is in progress
+func (i YahooTickerInfo) IsMarketOpen() bool {
+	return i.MarketStatus() == MarketStatusRegular
+}
+
+// IsPreMarket reports whether the pre-market session is in progress
+func (i YahooTickerInfo) IsPreMarket() bool {
+	return i.MarketStatus() == MarketStatusPre
+}
+
+// IsPostMarket reports whether the after-hours session is in progress
+func (i YahooTickerInfo) IsPostMarket() bool {
+	return i.MarketStatus() == MarketStatusPost
+}
+
+// CurrentPrice returns the live price for the market state: PreMarketPrice during the pre-market,
+// PostMarketPrice during and after the after-hours session, and RegularMarketPrice otherwise or when
+// the extended-hours price is missing. ErrNoData is returned when no price applies.
+func (i YahooTickerInfo) CurrentPrice() (PriceValue, error) {
+	switch i.MarketStatus() {
+	case MarketStatusPre:
+		if i.PreMarketPrice != nil {
+			return *i.PreMarketPrice, nil
+		}
+	case MarketStatusPost, MarketStatusPostPost:
+		if i.PostMarketPrice != nil {
+			return *i.PostMarketPrice, nil
+		}
+	}
+
+	if i.RegularMarketPrice != nil {
+		return *i.RegularMarketPrice, nil
+	}
+	return PriceValue{}, fmt.Errorf("current price not available for symbol %s: %w", i.Symbol, ErrNoData)
+}
+
+// FetchCurrentPrice retrieves the ticker's live price, including the pre-market and after-hours
+// prices that FetchPriceValue leaves out. See YahooTickerInfo.CurrentPrice.
+func (t *Ticker) FetchCurrentPrice() (PriceValue, error) {
+	info, err := t.FetchInformation()
+	if err != nil {
+		t.Client.log().Error("Failed to fetch ticker current price", "err", err)
+		return PriceValue{}, err
+	}
+	return info.CurrentPrice()
+}
+
+// MarketState retrieves the raw market state of the ticker's exchange, such as "PRE", "REGULAR", "POST"
+// or "CLOSED", with a single quote request. ErrNoData is returned when Yahoo doesn't report it.
+func (t *Ticker) MarketState() (string, error) {
+	info, err := t.FetchFastInfo()
+	if err != nil {
+		return "", err
+	}
+	if info.MarketState == "" {
+		return "", fmt.Errorf("market state not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+	return info.MarketState, nil
+}
+
+// IsMarketOpen reports whether the regular session of the ticker's exchange is in progress, with a single
+// quote request, e.g. to decide whether a dashboard should keep refreshing intraday
+func (t *Ticker) IsMarketOpen() (bool, error) {
+	state, err := t.MarketState()
+	if err != nil {
+		return false, err
+	}
+	return parseMarketStatus(state) == MarketStatusRegular, nil
+}
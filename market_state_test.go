@@ -0,0 +1,98 @@
+package yfinance_api
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestMarketStatus tests the typed market state and its helpers
+func TestMarketStatus(t *testing.T) {
+	testCases := map[string]struct {
+		expected                    MarketStatus
+		open, preMarket, postMarket bool
+	}{
+		"REGULAR":  {expected: MarketStatusRegular, open: true},
+		"PRE":      {expected: MarketStatusPre, preMarket: true},
+		"post":     {expected: MarketStatusPost, postMarket: true},
+		"POSTPOST": {expected: MarketStatusPostPost},
+		"CLOSED":   {expected: MarketStatusClosed},
+		"HALTED":   {expected: MarketStatusUnknown},
+		"":         {expected: MarketStatusUnknown},
+	}
+
+	for state, tc := range testCases {
+		info := YahooTickerInfo{MarketState: state}
+		if got := info.MarketStatus(); got != tc.expected {
+			t.Errorf("%q: expected %q, got %q", state, tc.expected, got)
+		}
+		if info.IsMarketOpen() != tc.open || info.IsPreMarket() != tc.preMarket || info.IsPostMarket() != tc.postMarket {
+			t.Errorf("%q: unexpected helpers open=%v pre=%v post=%v", state, info.IsMarketOpen(), info.IsPreMarket(), info.IsPostMarket())
+		}
+	}
+}
+
+// TestCurrentPrice tests that the extended-hours prices are picked by market state, falling back to the regular price
+func TestCurrentPrice(t *testing.T) {
+	info := YahooTickerInfo{
+		Symbol:             "AAPL",
+		PreMarketPrice:     &PriceValue{Raw: 189},
+		RegularMarketPrice: &PriceValue{Raw: 190},
+		PostMarketPrice:    &PriceValue{Raw: 191},
+	}
+	testCases := map[string]float64{"PRE": 189, "REGULAR": 190, "POST": 191, "POSTPOST": 191, "CLOSED": 190, "PREPRE": 190}
+	for state, expected := range testCases {
+		info.MarketState = state
+		if price, err := info.CurrentPrice(); err != nil || price.Raw != expected {
+			t.Errorf("%s: expected %v, got %v (%v)", state, expected, price.Raw, err)
+		}
+	}
+
+	info.MarketState, info.PostMarketPrice = "POST", nil
+	if price, err := info.CurrentPrice(); err != nil || price.Raw != 190 {
+		t.Errorf("Expected the regular price without a post-market price, got %v (%v)", price.Raw, err)
+	}
+	if _, err := (YahooTickerInfo{MarketState: "PRE"}).CurrentPrice(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without any price, got %v", err)
+	}
+}
+
+// TestFetchCurrentPrice tests that the post-market price is returned during after-hours
+func TestFetchCurrentPrice(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"price":{"symbol":"AAPL","marketState":"POST",
+		"regularMarketPrice":{"raw":190.5},"postMarketPrice":{"raw":191.2}}}`)
+
+	price, err := ticker.FetchCurrentPrice()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if price.Raw != 191.2 {
+		t.Errorf("Expected the post-market price 191.2, got %v", price.Raw)
+	}
+}
+
+// TestTickerIsMarketOpen tests the market state helpers, which cost a single quote request
+func TestTickerIsMarketOpen(t *testing.T) {
+	doer := &fakeDoer{body: `{"quoteResponse":{"result":[{"symbol":"AAPL","marketState":"REGULAR"}],"error":null}}`}
+	ticker := (&YFinanceAPI{Client: newTestClient(WithDoer(doer))}).InstantiateTicker("AAPL")
+
+	open, err := ticker.IsMarketOpen()
+	if err != nil || !open {
+		t.Errorf("Expected the market to be open, got %v (%v)", open, err)
+	}
+	if got := len(doer.requests); got != 1 {
+		t.Errorf("Expected a single request, got %d", got)
+	}
+
+	doer.body = `{"quoteResponse":{"result":[{"symbol":"AAPL","marketState":"POST"}],"error":null}}`
+	if state, err := ticker.MarketState(); err != nil || state != "POST" {
+		t.Errorf("Expected POST, got %q (%v)", state, err)
+	}
+	if open, err := ticker.IsMarketOpen(); err != nil || open {
+		t.Errorf("Expected the market to be closed after hours, got %v (%v)", open, err)
+	}
+
+	doer.body = `{"quoteResponse":{"result":[{"symbol":"AAPL"}],"error":null}}`
+	if _, err := ticker.MarketState(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without a market state, got %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package yfinance_api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// setBaseUrl points BaseUrl at a test server for the duration of the test
+func setBaseUrl(t *testing.T, url string) {
+	previous := BaseUrl
+	BaseUrl = url
+	t.Cleanup(func() { BaseUrl = previous })
+}
+
+// TestFetchGlobalMarketSummary tests concurrent region fetches with a failing region and duplicate spellings
+func TestFetchGlobalMarketSummary(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		region := r.URL.Query().Get("region")
+		if region == "XX" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"marketSummaryResponse":{"result":[],"error":null}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"marketSummaryResponse":{"result":[{"symbol":"^%s","regularMarketPrice":{"raw":100.5,"fmt":"100.50"}}],"error":null}}`, region)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	client := &YFinanceAPI{Client: newTestClient()}
+	summaries, err := client.FetchGlobalMarketSummary([]string{"US", "gb", "XX", "us", ""})
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("Expected a BatchError, got %v", err)
+	}
+	if len(batchErr.Errors) != 1 || batchErr.Errors["XX"] == nil {
+		t.Errorf("Expected only region XX to fail, got %v", batchErr.Errors)
+	}
+
+	if len(summaries) != 2 {
+		t.Fatalf("Expected 2 region summaries, got %d", len(summaries))
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("Expected one request per normalized region, got %d", n)
+	}
+	for _, region := range []string{"US", "GB"} {
+		items := summaries[region]
+		if len(items) != 1 || items[0].Symbol != "^"+region {
+			t.Errorf("Unexpected summary for %s: %+v", region, items)
+			continue
+		}
+		if items[0].RegularMarketPrice == nil || items[0].RegularMarketPrice.Raw != 100.5 {
+			t.Errorf("Expected regular market price 100.5 for %s", region)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package yfinance_api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// OptionContract is one call or put of an options chain
+type OptionContract struct {
+	ContractSymbol    string    `json:"contractSymbol"`
+	Strike            float64   `json:"strike"`
+	Currency          string    `json:"currency"`
+	LastPrice         float64   `json:"lastPrice"`
+	Change            float64   `json:"change"`
+	PercentChange     float64   `json:"percentChange"`
+	Bid               float64   `json:"bid"`
+	Ask               float64   `json:"ask"`
+	Volume            int64     `json:"volume"`
+	OpenInterest      int64     `json:"openInterest"`
+	ImpliedVolatility float64   `json:"impliedVolatility"` // As a fraction, e.g. 0.25 for 25%
+	InTheMoney        bool      `json:"inTheMoney"`
+	Expiration        time.Time `json:"expiration"`
+	LastTradeDate     time.Time `json:"lastTradeDate"`
+}
+
+// OptionChain is the options chain of a ticker for a single expiration date
+type OptionChain struct {
+	UnderlyingSymbol string           `json:"underlyingSymbol"`
+	ExpirationDates  []time.Time      `json:"expirationDates"` // All available expirations, in UTC
+	Strikes          []float64        `json:"strikes"`         // All strikes listed for the expiration
+	Expiration       time.Time        `json:"expiration"`      // Expiration of the calls and puts below
+	Calls            []OptionContract `json:"calls"`
+	Puts             []OptionContract `json:"puts"`
+}
+
+// yahooOptionContract is an option contract as returned by Yahoo, with epoch timestamps
+type yahooOptionContract struct {
+	ContractSymbol    string  `json:"contractSymbol"`
+	Strike            float64 `json:"strike"`
+	Currency          string  `json:"currency"`
+	LastPrice         float64 `json:"lastPrice"`
+	Change            float64 `json:"change"`
+	PercentChange     float64 `json:"percentChange"`
+	Bid               float64 `json:"bid"`
+	Ask               float64 `json:"ask"`
+	Volume            int64   `json:"volume"`
+	OpenInterest      int64   `json:"openInterest"`
+	ImpliedVolatility float64 `json:"impliedVolatility"`
+	InTheMoney        bool    `json:"inTheMoney"`
+	Expiration        int64   `json:"expiration"`
+	LastTradeDate     int64   `json:"lastTradeDate"`
+}
+
+// yahooOptionResult is the first result of the v7 options endpoint
+type yahooOptionResult struct {
+	UnderlyingSymbol string    `json:"underlyingSymbol"`
+	ExpirationDates  []int64   `json:"expirationDates"`
+	Strikes          []float64 `json:"strikes"`
+	Options          []struct {
+		ExpirationDate int64                 `json:"expirationDate"`
+		Calls          []yahooOptionContract `json:"calls"`
+		Puts           []yahooOptionContract `json:"puts"`
+	} `json:"options"`
+}
+
+// FetchOptions retrieves the options chain of the ticker for the given expiration, either as a date
+// ("2024-06-21") or as the Unix timestamp returned by FetchOptionExpirations.
+// When expiration is empty the nearest expiration is returned.
+func (t *Ticker) FetchOptions(expiration string) (OptionChain, error) {
+	params := url.Values{}
+	if expiration != "" {
+		expiry, err := parseExpiration(expiration)
+		if err != nil {
+			return OptionChain{}, err
+		}
+		params.Add("date", strconv.FormatInt(expiry.Unix(), 10))
+	}
+
+	result, err := t.fetchOptions(params)
+	if err != nil {
+		return OptionChain{}, err
+	}
+	if len(result.Options) == 0 {
+		return OptionChain{}, fmt.Errorf("no options found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	options := result.Options[0]
+	chain := OptionChain{
+		UnderlyingSymbol: result.UnderlyingSymbol,
+		ExpirationDates:  unixTimes(result.ExpirationDates),
+		Strikes:          result.Strikes,
+		Expiration:       time.Unix(options.ExpirationDate, 0).UTC(),
+		Calls:            optionContracts(options.Calls),
+		Puts:             optionContracts(options.Puts),
+	}
+
+	return chain, nil
+}
+
+// FetchOptionExpirations returns the available option expiration dates of the ticker, in UTC,
+// so that callers can pick one to pass to FetchOptions.
+func (t *Ticker) FetchOptionExpirations() ([]time.Time, error) {
+	result, err := t.fetchOptions(url.Values{})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.ExpirationDates) == 0 {
+		return nil, fmt.Errorf("no option expirations found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return unixTimes(result.ExpirationDates), nil
+}
+
+// fetchOptions requests the v7 options endpoint for the ticker and returns its first result
+func (t *Ticker) fetchOptions(params url.Values) (yahooOptionResult, error) {
+	endpoint := fmt.Sprintf("%s/v7/finance/options/%s", t.Client.baseURL(), t.Symbol)
+
+	resp, err := t.get(endpoint, params)
+	if err != nil {
+		t.Client.log().Error("Failed to get options", "err", err)
+		return yahooOptionResult{}, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.Client.log().Error("Failed to close response body", "err", err)
+		}
+	}(resp.Body)
+
+	var optionResponse struct {
+		OptionChain struct {
+			Result []yahooOptionResult `json:"result"`
+			Error  interface{}         `json:"error"`
+		} `json:"optionChain"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&optionResponse); err != nil {
+		return yahooOptionResult{}, fmt.Errorf("failed to decode options JSON response: %v", err)
+	}
+
+	if len(optionResponse.OptionChain.Result) == 0 {
+		return yahooOptionResult{}, emptyResultError("options", t.Symbol, optionResponse.OptionChain.Error)
+	}
+
+	return optionResponse.OptionChain.Result[0], nil
+}
+
+// parseExpiration accepts an expiration as a YYYY-MM-DD date or as Unix seconds
+func parseExpiration(expiration string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(expiration, 10, 64); err == nil {
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+	date, err := time.Parse("2006-01-02", expiration)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expiration %q, expected YYYY-MM-DD or Unix seconds: %w", expiration, ErrInvalidParameter)
+	}
+	return date, nil
+}
+
+// optionContracts converts Yahoo's contracts, turning their epoch timestamps into times
+func optionContracts(contracts []yahooOptionContract) []OptionContract {
+	converted := make([]OptionContract, 0, len(contracts))
+	for _, contract := range contracts {
+		converted = append(converted, OptionContract{
+			ContractSymbol:    contract.ContractSymbol,
+			Strike:            contract.Strike,
+			Currency:          contract.Currency,
+			LastPrice:         contract.LastPrice,
+			Change:            contract.Change,
+			PercentChange:     contract.PercentChange,
+			Bid:               contract.Bid,
+			Ask:               contract.Ask,
+			Volume:            contract.Volume,
+			OpenInterest:      contract.OpenInterest,
+			ImpliedVolatility: contract.ImpliedVolatility,
+			InTheMoney:        contract.InTheMoney,
+			Expiration:        time.Unix(contract.Expiration, 0).UTC(),
+			LastTradeDate:     time.Unix(contract.LastTradeDate, 0).UTC(),
+		})
+	}
+	return converted
+}
+
+// unixTimes converts Unix seconds into UTC times
+func unixTimes(seconds []int64) []time.Time {
+	times := make([]time.Time, len(seconds))
+	for i, s := range seconds {
+		times[i] = time.Unix(s, 0).UTC()
+	}
+	return times
+}
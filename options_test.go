@@ -0,0 +1,92 @@
+package yfinance_api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestFetchOptions tests parsing the options chain, with and without an expiration
+func TestFetchOptions(t *testing.T) {
+	var date string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		date = r.URL.Query().Get("date")
+		expiration := "1718928000"
+		if date != "" {
+			expiration = date
+		}
+		fmt.Fprintf(w, `{"optionChain":{"result":[{"underlyingSymbol":"AAPL",
+			"expirationDates":[1718928000,1719532800],"strikes":[190,195],
+			"options":[{"expirationDate":%s,
+				"calls":[{"contractSymbol":"AAPL240621C00190000","strike":190,"lastPrice":5.2,"bid":5.1,"ask":5.3,
+					"volume":1200,"openInterest":8000,"impliedVolatility":0.24,"inTheMoney":true,"expiration":%s,"lastTradeDate":1718380000}],
+				"puts":[{"contractSymbol":"AAPL240621P00195000","strike":195,"lastPrice":3.1,"inTheMoney":true,"expiration":%s}]}]}],
+			"error":null}}`, expiration, expiration, expiration)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+
+	chain, err := ticker.FetchOptions("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if date != "" {
+		t.Errorf("Expected no date parameter for the nearest expiration, got %q", date)
+	}
+	if len(chain.ExpirationDates) != 2 || !chain.ExpirationDates[0].Equal(time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected expiration dates: %v", chain.ExpirationDates)
+	}
+	if len(chain.Strikes) != 2 || len(chain.Calls) != 1 || len(chain.Puts) != 1 {
+		t.Fatalf("Unexpected chain: %+v", chain)
+	}
+	call := chain.Calls[0]
+	if call.Strike != 190 || call.Bid != 5.1 || call.Ask != 5.3 || call.OpenInterest != 8000 || call.ImpliedVolatility != 0.24 || !call.InTheMoney {
+		t.Errorf("Unexpected call: %+v", call)
+	}
+
+	chain, err = ticker.FetchOptions("2024-06-28")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if date != "1719532800" {
+		t.Errorf("Expected the date converted to Unix seconds, got %q", date)
+	}
+	if !chain.Expiration.Equal(time.Date(2024, 6, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected expiration: %v", chain.Expiration)
+	}
+
+	if _, err := ticker.FetchOptions("next friday"); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter for an invalid expiration, got %v", err)
+	}
+}
+
+// TestFetchOptionExpirations tests the expirations helper and a symbol without options
+func TestFetchOptionExpirations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v7/finance/options/NOOPT" {
+			fmt.Fprint(w, `{"optionChain":{"result":[{"underlyingSymbol":"NOOPT","expirationDates":[],"strikes":[],"options":[]}],"error":null}}`)
+			return
+		}
+		fmt.Fprint(w, `{"optionChain":{"result":[{"expirationDates":[1718928000,1719532800],"options":[]}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	client := &YFinanceAPI{Client: newTestClient()}
+	expirations, err := client.InstantiateTicker("AAPL").FetchOptionExpirations()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(expirations) != 2 || !expirations[1].Equal(time.Date(2024, 6, 28, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected expirations: %v", expirations)
+	}
+
+	if _, err := client.InstantiateTicker("NOOPT").FetchOptionExpirations(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData for a symbol without options, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package yfinance_api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+)
+
+// FetchPeers returns symbols comparable to the ticker, for use with comparison features such as CompareRatios.
+// Yahoo's recommended symbols are used first; when there are none, equities of the same industry
+// (from the assetProfile module) are looked up through the search endpoint instead.
+// An empty slice is returned when no peers can be found.
+func (t *Ticker) FetchPeers() ([]string, error) {
+	peers, err := t.fetchRecommendedSymbols()
+	if err != nil {
+		return nil, err
+	}
+	if len(peers) > 0 {
+		return peers, nil
+	}
+
+	result, err := t.fetchQuoteSummary("assetProfile")
+	if err != nil {
+		return nil, err
+	}
+
+	var summary struct {
+		AssetProfile *struct {
+			Sector   string `json:"sector"`
+			Industry string `json:"industry"`
+		} `json:"assetProfile"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode asset profile JSON response: %v", err)
+	}
+
+	if summary.AssetProfile == nil || summary.AssetProfile.Industry == "" {
+		return []string{}, nil
+	}
+
+	return t.fetchIndustryPeers(summary.AssetProfile.Industry)
+}
+
+// fetchRecommendedSymbols retrieves the symbols Yahoo recommends alongside the ticker
+func (t *Ticker) fetchRecommendedSymbols() ([]string, error) {
+	endpoint := fmt.Sprintf("%s/v6/finance/recommendationsbysymbol/%s", t.Client.baseURL(), t.Symbol)
+
+	resp, err := t.get(endpoint, url.Values{})
+	if err != nil {
+		t.Client.log().Error("Failed to get recommended symbols", "err", err)
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.Client.log().Error("Failed to close response body", "err", err)
+		}
+	}(resp.Body)
+
+	var recommendationsResponse struct {
+		Finance struct {
+			Result []struct {
+				Symbol             string `json:"symbol"`
+				RecommendedSymbols []struct {
+					Symbol string  `json:"symbol"`
+					Score  float64 `json:"score"`
+				} `json:"recommendedSymbols"`
+			} `json:"result"`
+			Error interface{} `json:"error"`
+		} `json:"finance"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&recommendationsResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode recommended symbols JSON response: %v", err)
+	}
+
+	// An empty result without explanation just means no recommendations, left to the industry fallback
+	if len(recommendationsResponse.Finance.Result) == 0 && newYahooError(recommendationsResponse.Finance.Error) != nil {
+		return nil, emptyResultError("recommended symbols", t.Symbol, recommendationsResponse.Finance.Error)
+	}
+
+	peers := []string{}
+	for _, result := range recommendationsResponse.Finance.Result {
+		for _, recommended := range result.RecommendedSymbols {
+			if recommended.Symbol != "" && recommended.Symbol != t.Symbol {
+				peers = append(peers, recommended.Symbol)
+			}
+		}
+	}
+	return peers, nil
+}
+
+// fetchIndustryPeers searches for equities whose industry matches the given one
+func (t *Ticker) fetchIndustryPeers(industry string) ([]string, error) {
+	results, err := t.Client.search(t.requestContext(), industry, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	peers := []string{}
+	for _, result := range results {
+		if result.QuoteType == "EQUITY" && result.Industry == industry && result.Symbol != t.Symbol {
+			peers = append(peers, result.Symbol)
+		}
+	}
+	return peers, nil
+}
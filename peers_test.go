@@ -0,0 +1,77 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newPeersTicker serves the given bodies by endpoint and returns a ticker bound to the test server
+func newPeersTicker(t *testing.T, symbol, recommendations, profile, search string) *Ticker {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v6/finance/recommendationsbysymbol/"):
+			fmt.Fprint(w, recommendations)
+		case strings.HasPrefix(r.URL.Path, "/v10/finance/quoteSummary/"):
+			fmt.Fprintf(w, `{"quoteSummary":{"result":[%s],"error":null}}`, profile)
+		case r.URL.Path == "/v1/finance/search":
+			fmt.Fprint(w, search)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	setBaseUrl(t, server.URL)
+
+	return (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker(symbol)
+}
+
+// TestFetchPeers tests the recommended symbols path
+func TestFetchPeers(t *testing.T) {
+	ticker := newPeersTicker(t, "AAPL",
+		`{"finance":{"result":[{"symbol":"AAPL","recommendedSymbols":[{"symbol":"MSFT","score":0.3},{"symbol":"GOOG","score":0.2}]}],"error":null}}`,
+		`{}`, `{}`)
+
+	peers, err := ticker.FetchPeers()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(peers, []string{"MSFT", "GOOG"}) {
+		t.Errorf("Expected [MSFT GOOG], got %v", peers)
+	}
+}
+
+// TestFetchPeersIndustryFallback tests falling back to same-industry equities
+func TestFetchPeersIndustryFallback(t *testing.T) {
+	ticker := newPeersTicker(t, "KO",
+		`{"finance":{"result":[{"symbol":"KO","recommendedSymbols":[]}],"error":null}}`,
+		`{"assetProfile":{"sector":"Consumer Defensive","industry":"Beverages - Non-Alcoholic"}}`,
+		`{"quotes":[{"symbol":"KO","quoteType":"EQUITY","industry":"Beverages - Non-Alcoholic"},
+			{"symbol":"PEP","quoteType":"EQUITY","industry":"Beverages - Non-Alcoholic"},
+			{"symbol":"BEVFX","quoteType":"MUTUALFUND"},
+			{"symbol":"STZ","quoteType":"EQUITY","industry":"Beverages - Brewers"}]}`)
+
+	peers, err := ticker.FetchPeers()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(peers, []string{"PEP"}) {
+		t.Errorf("Expected [PEP], got %v", peers)
+	}
+}
+
+// TestFetchPeersNone tests that no peers yields an empty slice rather than an error
+func TestFetchPeersNone(t *testing.T) {
+	ticker := newPeersTicker(t, "TINY", `{"finance":{"result":[],"error":null}}`, `{"assetProfile":{}}`, `{}`)
+
+	peers, err := ticker.FetchPeers()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if peers == nil || len(peers) != 0 {
+		t.Errorf("Expected an empty slice, got %#v", peers)
+	}
+}
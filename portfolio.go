@@ -0,0 +1,157 @@
+package yfinance_api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReturnsMatrix fetches the histories of several symbols concurrently and returns their simple returns
+// on the dates every symbol traded, the usual input to covariance and portfolio optimization routines.
+// dates[i] is the date returns[symbol][i] ends on, so each series has len(dates) values. Dates are taken
+// from each exchange's local calendar, so daily bars of different markets line up by trading date.
+// Any symbol failing to download fails the whole matrix; ErrNoData is returned with fewer than two common dates.
+func (c *YFinanceAPI) ReturnsMatrix(symbols []string, rangeParam, interval string) (dates []time.Time, returns map[string][]float64, err error) {
+	// Bootstrap the crumb once up front rather than from every goroutine
+	c.Client.getCrumb(context.Background())
+
+	var mu sync.Mutex
+	histories := make(map[string]map[string]PriceData, len(symbols))
+
+	err = forEachConcurrent(symbols, DefaultConcurrency, func(symbol string) error {
+		data, err := c.InstantiateTicker(symbol).FetchHistoricalData(rangeParam, interval, "", "")
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		histories[symbol] = data
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return alignedReturns(histories)
+}
+
+// Correlation returns the Pearson correlation of the returns of two symbols over their common dates,
+// from -1 to 1. Histories are fetched and aligned as by ReturnsMatrix, and ErrNoData is returned when
+// there are fewer than two common returns or either symbol's returns are constant.
+func (c *YFinanceAPI) Correlation(symbolA, symbolB, rangeParam, interval string) (float64, error) {
+	_, returns, err := c.ReturnsMatrix([]string{symbolA, symbolB}, rangeParam, interval)
+	if err != nil {
+		return 0, err
+	}
+
+	a, b := returns[symbolA], returns[symbolB]
+	varianceA, varianceB := covariance(a, a), covariance(b, b)
+	if len(a) < 2 || varianceA == 0 || varianceB == 0 {
+		return 0, fmt.Errorf("not enough varying returns to correlate %s and %s: %w", symbolA, symbolB, ErrNoData)
+	}
+	return covariance(a, b) / math.Sqrt(varianceA*varianceB), nil
+}
+
+// Beta returns the slope of the regression of symbol's returns on those of benchmark, such as "^GSPC",
+// over their common dates: the covariance of both returns divided by the variance of the benchmark's.
+// Histories are fetched and aligned as by ReturnsMatrix, and ErrNoData is returned when there are fewer
+// than two common returns or the benchmark's returns are constant.
+func (c *YFinanceAPI) Beta(symbol, benchmark, rangeParam, interval string) (float64, error) {
+	_, returns, err := c.ReturnsMatrix([]string{symbol, benchmark}, rangeParam, interval)
+	if err != nil {
+		return 0, err
+	}
+
+	market := returns[benchmark]
+	variance := covariance(market, market)
+	if len(market) < 2 || variance == 0 {
+		return 0, fmt.Errorf("not enough varying returns of %s to compute the beta of %s: %w", benchmark, symbol, ErrNoData)
+	}
+	return covariance(returns[symbol], market) / variance, nil
+}
+
+// covariance returns the sample covariance of two series of the same length, 0 with fewer than two values
+func covariance(x, y []float64) float64 {
+	if len(x) < 2 {
+		return 0
+	}
+
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+
+	sum := 0.0
+	for i := range x {
+		sum += (x[i] - meanX) * (y[i] - meanY)
+	}
+	return sum / float64(len(x)-1)
+}
+
+// alignedReturns computes the returns of each history on the keys present, with a close, in all of them
+func alignedReturns(histories map[string]map[string]PriceData) ([]time.Time, map[string][]float64, error) {
+	var common []string
+	first := true
+	for _, history := range histories {
+		if first {
+			for key, price := range history {
+				if price.Close != nil {
+					common = append(common, key)
+				}
+			}
+			first = false
+			continue
+		}
+
+		kept := common[:0]
+		for _, key := range common {
+			if price, ok := history[key]; ok && price.Close != nil {
+				kept = append(kept, key)
+			}
+		}
+		common = kept
+	}
+
+	if len(common) < 2 {
+		return nil, nil, fmt.Errorf("only %d common dates across symbols: %w", len(common), ErrNoData)
+	}
+	sort.Strings(common)
+
+	dates := make([]time.Time, 0, len(common)-1)
+	for _, key := range common[1:] {
+		date, err := parseHistoryKey(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		dates = append(dates, date)
+	}
+
+	returns := make(map[string][]float64, len(histories))
+	for symbol, history := range histories {
+		series := make([]float64, 0, len(common)-1)
+		for i := 1; i < len(common); i++ {
+			previous, current := *history[common[i-1]].Close, *history[common[i]].Close
+			series = append(series, current/previous-1)
+		}
+		returns[symbol] = series
+	}
+
+	return dates, returns, nil
+}
+
+// parseHistoryKey parses the date or date-time keys produced by transformHistoricalData
+func parseHistoryKey(key string) (time.Time, error) {
+	layout := "2006-01-02"
+	if strings.Contains(key, " ") {
+		layout = "2006-01-02 15:04:05"
+	}
+	return time.Parse(layout, key)
+}
@@ -0,0 +1,99 @@
+package yfinance_api
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestAlignedReturns tests computing returns on the dates common to every symbol
+func TestAlignedReturns(t *testing.T) {
+	histories := map[string]map[string]PriceData{
+		"AAPL": {
+			"2024-01-02": {Close: floatPtr(100)},
+			"2024-01-03": {Close: floatPtr(110)},
+			"2024-01-04": {Close: floatPtr(99)},
+			"2024-01-05": {Close: floatPtr(120)},
+		},
+		"7203.T": {
+			"2024-01-02": {Close: floatPtr(50)},
+			"2024-01-03": {Close: nil}, // no close, so the date is dropped for everyone
+			"2024-01-04": {Close: floatPtr(60)},
+			"2024-01-05": {Close: floatPtr(45)},
+		},
+	}
+
+	dates, returns, err := alignedReturns(histories)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(dates) != 2 || dates[0].Format("2006-01-02") != "2024-01-04" || dates[1].Format("2006-01-02") != "2024-01-05" {
+		t.Fatalf("Expected dates [2024-01-04 2024-01-05], got %v", dates)
+	}
+
+	expected := map[string][]float64{
+		"AAPL":   {-0.01, 120.0/99 - 1},
+		"7203.T": {0.2, -0.25},
+	}
+	for symbol, series := range expected {
+		if len(returns[symbol]) != len(series) {
+			t.Fatalf("Expected %d returns for %s, got %v", len(series), symbol, returns[symbol])
+		}
+		for i := range series {
+			if math.Abs(returns[symbol][i]-series[i]) > 1e-9 {
+				t.Errorf("Expected %s return %d to be %v, got %v", symbol, i, series[i], returns[symbol][i])
+			}
+		}
+	}
+}
+
+// TestAlignedReturnsNoOverlap tests that histories without enough common dates return ErrNoData
+func TestAlignedReturnsNoOverlap(t *testing.T) {
+	histories := map[string]map[string]PriceData{
+		"A": {"2024-01-02": {Close: floatPtr(1)}, "2024-01-03": {Close: floatPtr(2)}},
+		"B": {"2024-01-03": {Close: floatPtr(1)}, "2024-01-04": {Close: floatPtr(2)}},
+	}
+
+	if _, _, err := alignedReturns(histories); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+// TestCorrelationAndBeta tests both statistics on histories aligned by date, one of them missing a date
+func TestCorrelationAndBeta(t *testing.T) {
+	closes := map[string]string{
+		"^GSPC": `[100,110,99,108.9,119.79]`,
+		"AAPL":  `[100,120,96,115.2,null]`,
+		"XOM":   `[50,45,49.5,44.55,40.095]`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbol := strings.TrimPrefix(r.URL.Path, "/v8/finance/chart/")
+		fmt.Fprintf(w, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","dataGranularity":"1d"},
+			"timestamp":[1704205800,1704292200,1704378600,1704465000,1704724200],
+			"indicators":{"quote":[{"open":%[1]s,"high":%[1]s,"low":%[1]s,"close":%[1]s,"volume":[1,1,1,1,1]}]}}],"error":null}}`, closes[symbol])
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+	api := &YFinanceAPI{Client: newTestClient()}
+
+	// AAPL moves twice as much as the index, and XOM exactly against it; AAPL's last date is left out
+	if beta, err := api.Beta("AAPL", "^GSPC", "5d", "1d"); err != nil || math.Abs(beta-2) > 1e-9 {
+		t.Errorf("Expected a beta of 2, got %v (%v)", beta, err)
+	}
+	if correlation, err := api.Correlation("AAPL", "^GSPC", "5d", "1d"); err != nil || math.Abs(correlation-1) > 1e-9 {
+		t.Errorf("Expected a correlation of 1, got %v (%v)", correlation, err)
+	}
+	if correlation, err := api.Correlation("XOM", "^GSPC", "5d", "1d"); err != nil || math.Abs(correlation+1) > 1e-9 {
+		t.Errorf("Expected a correlation of -1, got %v (%v)", correlation, err)
+	}
+
+	closes["FLAT"] = `[10,10,10,10,10]`
+	if _, err := api.Beta("AAPL", "FLAT", "5d", "1d"); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData against a constant benchmark, got %v", err)
+	}
+}
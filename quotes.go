@@ -0,0 +1,283 @@
+package yfinance_api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// FastInfo is a compact quote for high-frequency polling. Missing values are zero.
+type FastInfo struct {
+	Symbol        string  `json:"symbol"`
+	Currency      string  `json:"currency"`
+	MarketState   string  `json:"marketState"` // e.g. "PRE", "REGULAR", "POST" or "CLOSED"
+	LastPrice     float64 `json:"lastPrice"`
+	Change        float64 `json:"change"`
+	ChangePercent float64 `json:"changePercent"` // In percent, e.g. 1.2 for +1.2%
+	PreviousClose float64 `json:"previousClose"`
+	DayHigh       float64 `json:"dayHigh"`
+	DayLow        float64 `json:"dayLow"`
+	Volume        float64 `json:"volume"`
+	MarketCap     float64 `json:"marketCap"`
+}
+
+// fastInfoFields are the only fields requested from the quote endpoint by FetchFastInfo
+const fastInfoFields = "currency,marketState,regularMarketPrice,regularMarketChange,regularMarketChangePercent,regularMarketPreviousClose,regularMarketDayHigh,regularMarketDayLow,regularMarketVolume,marketCap"
+
+// FetchFastInfo retrieves the last price and change, previous close, day range, volume, market cap, currency
+// and market state of the ticker
+// from the quote endpoint, asking Yahoo for those fields only. It is much lighter than FetchInformation
+// and meant for dashboards polling many symbols.
+func (t *Ticker) FetchFastInfo() (FastInfo, error) {
+	params := url.Values{}
+	params.Add("symbols", t.Symbol)
+	params.Add("fields", fastInfoFields)
+
+	endpoint := fmt.Sprintf("%s/v7/finance/quote", t.Client.baseURL())
+
+	resp, err := t.get(endpoint, params)
+	if err != nil {
+		t.Client.log().Error("Failed to get fast info", "err", err)
+		return FastInfo{}, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.Client.log().Error("Failed to close response body", "err", err)
+		}
+	}(resp.Body)
+
+	// PriceValue values rather than pointers, so that decoding doesn't allocate per field
+	var quoteResponse struct {
+		QuoteResponse struct {
+			Result []struct {
+				Symbol                     string     `json:"symbol"`
+				Currency                   string     `json:"currency"`
+				MarketState                string     `json:"marketState"`
+				RegularMarketPrice         PriceValue `json:"regularMarketPrice"`
+				RegularMarketChange        PriceValue `json:"regularMarketChange"`
+				RegularMarketChangePercent PriceValue `json:"regularMarketChangePercent"`
+				RegularMarketPreviousClose PriceValue `json:"regularMarketPreviousClose"`
+				RegularMarketDayHigh       PriceValue `json:"regularMarketDayHigh"`
+				RegularMarketDayLow        PriceValue `json:"regularMarketDayLow"`
+				RegularMarketVolume        PriceValue `json:"regularMarketVolume"`
+				MarketCap                  PriceValue `json:"marketCap"`
+			} `json:"result"`
+			Error interface{} `json:"error"`
+		} `json:"quoteResponse"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&quoteResponse); err != nil {
+		return FastInfo{}, fmt.Errorf("failed to decode fast info JSON response: %v", err)
+	}
+
+	if len(quoteResponse.QuoteResponse.Result) == 0 {
+		return FastInfo{}, emptyResultError("quote", t.Symbol, quoteResponse.QuoteResponse.Error)
+	}
+
+	quote := quoteResponse.QuoteResponse.Result[0]
+	return FastInfo{
+		Symbol:        quote.Symbol,
+		Currency:      quote.Currency,
+		MarketState:   quote.MarketState,
+		LastPrice:     quote.RegularMarketPrice.Raw,
+		Change:        quote.RegularMarketChange.Raw,
+		ChangePercent: quote.RegularMarketChangePercent.Raw,
+		PreviousClose: quote.RegularMarketPreviousClose.Raw,
+		DayHigh:       quote.RegularMarketDayHigh.Raw,
+		DayLow:        quote.RegularMarketDayLow.Raw,
+		Volume:        quote.RegularMarketVolume.Raw,
+		MarketCap:     quote.MarketCap.Raw,
+	}, nil
+}
+
+// FetchQuotes retrieves the quotes of several symbols, keyed by symbol. Symbols are sent QuoteBatchSize
+// at a time, with at most DefaultConcurrency requests in flight, and the results are merged.
+// Symbols unknown to Yahoo Finance are absent from the result. Batches that fail are reported through a
+// *BatchError keyed by their comma-separated symbols, alongside the quotes that did succeed.
+// An error is also returned when no quote was found at all.
+func (c *YFinanceAPI) FetchQuotes(symbols []string) (map[string]YahooTickerInfo, error) {
+	if len(symbols) == 0 {
+		return map[string]YahooTickerInfo{}, nil
+	}
+
+	batchSize := QuoteBatchSize
+	if batchSize <= 0 {
+		batchSize = len(symbols)
+	}
+	batches := make([]string, 0, (len(symbols)+batchSize-1)/batchSize)
+	for start := 0; start < len(symbols); start += batchSize {
+		end := min(start+batchSize, len(symbols))
+		batches = append(batches, strings.Join(symbols[start:end], ","))
+	}
+
+	// Bootstrap the crumb once up front rather than from every goroutine
+	c.Client.getCrumb(context.Background())
+
+	var mu sync.Mutex
+	quotes := make(map[string]YahooTickerInfo, len(symbols))
+
+	err := forEachConcurrent(batches, DefaultConcurrency, func(batch string) error {
+		batchQuotes, err := c.fetchQuoteBatch(batch)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		for symbol, quote := range batchQuotes {
+			quotes[symbol] = quote
+		}
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		return quotes, err
+	}
+
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("no quotes found for symbols %s: %w", strings.Join(symbols, ","), ErrSymbolNotFound)
+	}
+
+	return quotes, nil
+}
+
+// fetchQuoteBatch requests the quotes of a comma-separated list of symbols in a single call
+func (c *YFinanceAPI) fetchQuoteBatch(symbols string) (map[string]YahooTickerInfo, error) {
+	params := url.Values{}
+	params.Add("symbols", symbols)
+
+	endpoint := fmt.Sprintf("%s/v7/finance/quote", c.Client.baseURL())
+
+	resp, err := c.Client.Get(endpoint, params)
+	if err != nil {
+		c.Client.log().Error("Failed to get quotes", "err", err)
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			c.Client.log().Error("Failed to close response body", "err", err)
+		}
+	}(resp.Body)
+
+	quotes := make(map[string]YahooTickerInfo, strings.Count(symbols, ",")+1)
+	if err := decodeQuoteResponse(resp.Body, quotes); err != nil {
+		var yahooErr *YahooError
+		if errors.As(err, &yahooErr) {
+			return nil, fmt.Errorf("failed to get quotes for symbols %s: %w", symbols, yahooErr)
+		}
+		return nil, fmt.Errorf("failed to decode quotes JSON response: %v", err)
+	}
+
+	return quotes, nil
+}
+
+// decodeQuoteResponse streams a v7 quote response into quotes, decoding one result at a time
+// so that large batches never hold the whole result array in memory alongside the map.
+// When no quote was decoded and Yahoo sent an error object, it is returned as a *YahooError.
+func decodeQuoteResponse(r io.Reader, quotes map[string]YahooTickerInfo) error {
+	var yahooErr *YahooError
+	dec := json.NewDecoder(r)
+
+	// {"quoteResponse":{"result":[...],"error":null}}
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if key != "quoteResponse" {
+			if err := skipValue(dec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := expectDelim(dec, '{'); err != nil {
+			return err
+		}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if key == "error" {
+				var payload interface{}
+				if err := dec.Decode(&payload); err != nil {
+					return err
+				}
+				yahooErr = newYahooError(payload)
+				continue
+			}
+			if key != "result" {
+				if err := skipValue(dec); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := decodeQuoteResults(dec, quotes); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, '}'); err != nil {
+			return err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return err
+	}
+
+	if yahooErr != nil && len(quotes) == 0 {
+		return yahooErr
+	}
+	return nil
+}
+
+// decodeQuoteResults decodes the result array element by element, accepting null for an empty result
+func decodeQuoteResults(dec *json.Decoder, quotes map[string]YahooTickerInfo) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected result array, got %v", token)
+	}
+
+	for dec.More() {
+		var quote YahooTickerInfo
+		if err := dec.Decode(&quote); err != nil {
+			return err
+		}
+		if quote.Symbol != "" {
+			quotes[quote.Symbol] = quote
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token and checks that it is the given delimiter
+func expectDelim(dec *json.Decoder, expected json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("expected %v, got %v", expected, token)
+	}
+	return nil
+}
+
+// skipValue discards the next value, whatever its type
+func skipValue(dec *json.Decoder) error {
+	var discard json.RawMessage
+	return dec.Decode(&discard)
+}
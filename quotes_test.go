@@ -0,0 +1,243 @@
+package yfinance_api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestFetchQuotes tests fetching several quotes in a single request
+func TestFetchQuotes(t *testing.T) {
+	var symbols string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbols = r.URL.Query().Get("symbols")
+		fmt.Fprint(w, `{"quoteResponse":{"result":[
+			{"symbol":"AAPL","regularMarketPrice":189.84,"currency":"USD"},
+			{"symbol":"MSFT","regularMarketPrice":{"raw":415.5,"fmt":"415.50"},"currency":"USD"}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	client := &YFinanceAPI{Client: newTestClient()}
+	quotes, err := client.FetchQuotes([]string{"AAPL", "MSFT", "UNKNOWN"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if symbols != "AAPL,MSFT,UNKNOWN" {
+		t.Errorf("Expected all symbols in one request, got %q", symbols)
+	}
+	if len(quotes) != 2 {
+		t.Fatalf("Expected 2 quotes, got %d", len(quotes))
+	}
+	if price := quotes["AAPL"].RegularMarketPrice; price == nil || price.Raw != 189.84 {
+		t.Errorf("Expected AAPL price 189.84, got %+v", price)
+	}
+	if price := quotes["MSFT"].RegularMarketPrice; price == nil || price.Fmt != "415.50" {
+		t.Errorf("Expected MSFT formatted price 415.50, got %+v", price)
+	}
+}
+
+// TestFetchQuotesBatches tests that large symbol lists are split into batches and merged,
+// keeping the quotes of the batches that succeeded when another one fails
+func TestFetchQuotesBatches(t *testing.T) {
+	var mu sync.Mutex
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		symbols := strings.Split(r.URL.Query().Get("symbols"), ",")
+		mu.Lock()
+		batchSizes = append(batchSizes, len(symbols))
+		mu.Unlock()
+
+		if symbols[0] == "S100" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		results := make([]string, len(symbols))
+		for i, symbol := range symbols {
+			results[i] = fmt.Sprintf(`{"symbol":%q}`, symbol)
+		}
+		fmt.Fprintf(w, `{"quoteResponse":{"result":[%s]}}`, strings.Join(results, ","))
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	symbols := make([]string, 120)
+	for i := range symbols {
+		symbols[i] = fmt.Sprintf("S%d", i)
+	}
+
+	client := &YFinanceAPI{Client: newTestClient(WithRetryPolicy(nil))}
+	quotes, err := client.FetchQuotes(symbols)
+
+	sort.Ints(batchSizes)
+	if len(batchSizes) != 3 || batchSizes[0] != 20 || batchSizes[1] != 50 || batchSizes[2] != 50 {
+		t.Errorf("Expected batches of 50, 50 and 20 symbols, got %v", batchSizes)
+	}
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 {
+		t.Fatalf("Expected a BatchError for the failing batch, got %v", err)
+	}
+	if len(quotes) != 100 {
+		t.Errorf("Expected 100 quotes from the successful batches, got %d", len(quotes))
+	}
+	if _, ok := quotes["S99"]; !ok {
+		t.Error("Expected S99 to be in the merged result")
+	}
+}
+
+// TestDecodeQuoteResponse tests the streaming decoder on unusual but valid responses
+func TestDecodeQuoteResponse(t *testing.T) {
+	testCases := []struct {
+		name     string
+		body     string
+		expected int
+		wantErr  bool
+	}{
+		{name: "Null result", body: `{"quoteResponse":{"result":null,"error":null}}`, expected: 0},
+		{name: "Error before result", body: `{"quoteResponse":{"error":{"code":"x"},"result":[{"symbol":"A"}]}}`, expected: 1},
+		{name: "Unknown top-level key", body: `{"finance":{"result":[1,2]},"quoteResponse":{"result":[{"symbol":"A"},{"symbol":"B"}]}}`, expected: 2},
+		{name: "Error without result", body: `{"quoteResponse":{"result":null,"error":{"code":"Too Many Requests","description":"Rate limited"}}}`, wantErr: true},
+		{name: "Truncated", body: `{"quoteResponse":{"result":[{"symbol":"A"}`, wantErr: true},
+		{name: "Not an array", body: `{"quoteResponse":{"result":{"symbol":"A"}}}`, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			quotes := make(map[string]YahooTickerInfo)
+			err := decodeQuoteResponse(strings.NewReader(tc.body), quotes)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(quotes) != tc.expected {
+				t.Errorf("Expected %d quotes, got %d", tc.expected, len(quotes))
+			}
+		})
+	}
+}
+
+// TestPriceValueUnmarshalJSON tests decoding both object and plain number price values
+func TestPriceValueUnmarshalJSON(t *testing.T) {
+	var values struct {
+		Object *PriceValue `json:"object"`
+		Number *PriceValue `json:"number"`
+		Null   *PriceValue `json:"null"`
+	}
+	if err := json.Unmarshal([]byte(`{"object":{"raw":1.5,"fmt":"1.50"},"number":2.5,"null":null}`), &values); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if values.Object == nil || values.Object.Raw != 1.5 || values.Object.Fmt != "1.50" {
+		t.Errorf("Unexpected object value: %+v", values.Object)
+	}
+	if values.Number == nil || values.Number.Raw != 2.5 {
+		t.Errorf("Unexpected number value: %+v", values.Number)
+	}
+	if values.Null != nil {
+		t.Errorf("Expected nil for null, got %+v", values.Null)
+	}
+}
+
+// newMockQuoteResponse builds a quote response with n synthetic quotes
+func newMockQuoteResponse(n int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`{"quoteResponse":{"result":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"symbol":"SYM%d","shortName":"Company %d","longName":"Company %d Incorporated","currency":"USD",`+
+			`"exchange":"NMS","marketState":"REGULAR","quoteType":"EQUITY","regularMarketPrice":%d.25,"regularMarketChange":1.5,`+
+			`"regularMarketChangePercent":0.8,"regularMarketDayHigh":%d.5,"regularMarketDayLow":%d.1,"regularMarketVolume":1234567,`+
+			`"regularMarketPreviousClose":%d.0,"regularMarketOpen":%d.2,"marketCap":987654321000,"regularMarketTime":1718038800}`,
+			i, i, i, i, i, i, i, i)
+	}
+	buf.WriteString(`],"error":null}}`)
+	return buf.Bytes()
+}
+
+// BenchmarkDecodeQuoteResponse compares streaming the result array into the map
+// with unmarshaling the whole array first, for a large watchlist
+func BenchmarkDecodeQuoteResponse(b *testing.B) {
+	body := newMockQuoteResponse(2000)
+
+	b.Run("Streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			quotes := make(map[string]YahooTickerInfo, 2000)
+			if err := decodeQuoteResponse(bytes.NewReader(body), quotes); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("Unmarshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var response struct {
+				QuoteResponse struct {
+					Result []YahooTickerInfo `json:"result"`
+				} `json:"quoteResponse"`
+			}
+			if err := json.NewDecoder(bytes.NewReader(body)).Decode(&response); err != nil {
+				b.Fatal(err)
+			}
+			quotes := make(map[string]YahooTickerInfo, len(response.QuoteResponse.Result))
+			for _, quote := range response.QuoteResponse.Result {
+				quotes[quote.Symbol] = quote
+			}
+		}
+	})
+}
+
+// TestFetchFastInfo tests that only the fast fields are requested and returned as plain values
+func TestFetchFastInfo(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		if query.Get("symbols") != "AAPL" {
+			fmt.Fprint(w, `{"quoteResponse":{"result":[],"error":null}}`)
+			return
+		}
+		fmt.Fprint(w, `{"quoteResponse":{"result":[{"symbol":"AAPL","currency":"USD","marketState":"REGULAR","regularMarketPrice":189.84,
+			"regularMarketChange":1.34,"regularMarketChangePercent":0.71,"regularMarketPreviousClose":188.5,"regularMarketDayHigh":190.32,"regularMarketDayLow":187.6,
+			"regularMarketVolume":51234000,"marketCap":{"raw":2950000000000,"fmt":"2.95T"}}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	api := &YFinanceAPI{Client: newTestClient()}
+	info, err := api.InstantiateTicker("AAPL").FetchFastInfo()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := FastInfo{Symbol: "AAPL", Currency: "USD", MarketState: "REGULAR", LastPrice: 189.84, Change: 1.34,
+		ChangePercent: 0.71, PreviousClose: 188.5, DayHigh: 190.32,
+		DayLow: 187.6, Volume: 51234000, MarketCap: 2950000000000}
+	if info != expected {
+		t.Errorf("Expected %+v, got %+v", expected, info)
+	}
+	if query.Get("fields") != fastInfoFields {
+		t.Errorf("Expected only the fast fields to be requested, got %q", query.Get("fields"))
+	}
+
+	if _, err := api.InstantiateTicker("NOPE").FetchFastInfo(); !errors.Is(err, ErrSymbolNotFound) {
+		t.Errorf("Expected ErrSymbolNotFound for an unknown symbol, got %v", err)
+	}
+}
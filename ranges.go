@@ -0,0 +1,188 @@
+package yfinance_api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// PriceStatistics is the current price of a ticker along with its day and 52-week ranges and moving averages
+type PriceStatistics struct {
+	Price                *PriceValue `json:"price"`
+	DayLow               *PriceValue `json:"dayLow"`
+	DayHigh              *PriceValue `json:"dayHigh"`
+	FiftyTwoWeekLow      *PriceValue `json:"fiftyTwoWeekLow"`
+	FiftyTwoWeekHigh     *PriceValue `json:"fiftyTwoWeekHigh"`
+	FiftyDayAverage      *PriceValue `json:"fiftyDayAverage"`
+	TwoHundredDayAverage *PriceValue `json:"twoHundredDayAverage"`
+}
+
+// TechnicalLevels is the current price of a ticker along with its 52-week range and moving averages
+type TechnicalLevels struct {
+	Price                *PriceValue `json:"price"`
+	FiftyTwoWeekLow      *PriceValue `json:"fiftyTwoWeekLow"`
+	FiftyTwoWeekHigh     *PriceValue `json:"fiftyTwoWeekHigh"`
+	FiftyDayAverage      *PriceValue `json:"fiftyDayAverage"`
+	TwoHundredDayAverage *PriceValue `json:"twoHundredDayAverage"`
+	// AboveTwoHundredDayAverage reports whether the price is above its 200-day average,
+	// false when either is missing
+	AboveTwoHundredDayAverage bool `json:"aboveTwoHundredDayAverage"`
+}
+
+// DayRange returns the low and high of the current trading day.
+// ErrNoData is returned when either bound is missing.
+func (i YahooTickerInfo) DayRange() (low, high float64, err error) {
+	if i.RegularMarketDayLow == nil || i.RegularMarketDayHigh == nil {
+		return 0, 0, fmt.Errorf("day range not available for symbol %s: %w", i.Symbol, ErrNoData)
+	}
+	return i.RegularMarketDayLow.Raw, i.RegularMarketDayHigh.Raw, nil
+}
+
+// YearRange returns the 52-week low and high.
+// ErrNoData is returned when either bound is missing.
+func (s FinancialSummary) YearRange() (low, high float64, err error) {
+	if s.FiftyTwoWeekLow == nil || s.FiftyTwoWeekHigh == nil {
+		return 0, 0, fmt.Errorf("52-week range not available: %w", ErrNoData)
+	}
+	return s.FiftyTwoWeekLow.Raw, s.FiftyTwoWeekHigh.Raw, nil
+}
+
+// RangePosition returns where price sits within [low, high], from 0 at the low to 1 at the high,
+// clamped to that interval. It returns NaN when the range is empty or inverted.
+//
+//	low, high, _ := info.DayRange()
+//	position := RangePosition(info.RegularMarketPrice.Raw, low, high)
+func RangePosition(price, low, high float64) float64 {
+	if high <= low {
+		return math.NaN()
+	}
+	return math.Min(math.Max((price-low)/(high-low), 0), 1)
+}
+
+// FetchPriceStatistics retrieves the price ranges and moving averages of the ticker from summaryDetail.
+// It is much lighter than FetchKeyStatistics; only the current price, which summaryDetail lacks,
+// is read from the small price module. ErrNoData is returned when summaryDetail is missing.
+func (t *Ticker) FetchPriceStatistics() (PriceStatistics, error) {
+	result, err := t.fetchQuoteSummary("summaryDetail,price")
+	if err != nil {
+		return PriceStatistics{}, err
+	}
+
+	var summary struct {
+		SummaryDetail *struct {
+			RegularMarketDayLow  *PriceValue `json:"regularMarketDayLow"`
+			RegularMarketDayHigh *PriceValue `json:"regularMarketDayHigh"`
+			FiftyTwoWeekLow      *PriceValue `json:"fiftyTwoWeekLow"`
+			FiftyTwoWeekHigh     *PriceValue `json:"fiftyTwoWeekHigh"`
+			FiftyDayAverage      *PriceValue `json:"fiftyDayAverage"`
+			TwoHundredDayAverage *PriceValue `json:"twoHundredDayAverage"`
+		} `json:"summaryDetail"`
+		Price *struct {
+			RegularMarketPrice *PriceValue `json:"regularMarketPrice"`
+		} `json:"price"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return PriceStatistics{}, fmt.Errorf("failed to decode price statistics JSON response: %v", err)
+	}
+
+	if summary.SummaryDetail == nil {
+		return PriceStatistics{}, fmt.Errorf("no price statistics found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	detail := summary.SummaryDetail
+	statistics := PriceStatistics{
+		DayLow:               detail.RegularMarketDayLow,
+		DayHigh:              detail.RegularMarketDayHigh,
+		FiftyTwoWeekLow:      detail.FiftyTwoWeekLow,
+		FiftyTwoWeekHigh:     detail.FiftyTwoWeekHigh,
+		FiftyDayAverage:      detail.FiftyDayAverage,
+		TwoHundredDayAverage: detail.TwoHundredDayAverage,
+	}
+	if summary.Price != nil {
+		statistics.Price = summary.Price.RegularMarketPrice
+	}
+	return statistics, nil
+}
+
+// PercentOf52WeekRange returns where the current price sits within the 52-week range, from 0 at the low
+// to 1 at the high, as computed by RangePosition. ErrNoData is returned when the price or a bound is missing.
+func (s PriceStatistics) PercentOf52WeekRange() (float64, error) {
+	if s.Price == nil || s.FiftyTwoWeekLow == nil || s.FiftyTwoWeekHigh == nil {
+		return 0, fmt.Errorf("price or 52-week range not available: %w", ErrNoData)
+	}
+	return RangePosition(s.Price.Raw, s.FiftyTwoWeekLow.Raw, s.FiftyTwoWeekHigh.Raw), nil
+}
+
+// AboveFiftyDayAverage reports whether the current price is above its 50-day moving average.
+// ErrNoData is returned when the price or the average is missing.
+func (s PriceStatistics) AboveFiftyDayAverage() (bool, error) {
+	if s.Price == nil || s.FiftyDayAverage == nil {
+		return false, fmt.Errorf("price or 50-day average not available: %w", ErrNoData)
+	}
+	return s.Price.Raw > s.FiftyDayAverage.Raw, nil
+}
+
+// AboveTwoHundredDayAverage reports whether the current price is above its 200-day moving average,
+// a common momentum check. ErrNoData is returned when the price or the average is missing.
+func (s PriceStatistics) AboveTwoHundredDayAverage() (bool, error) {
+	if s.Price == nil || s.TwoHundredDayAverage == nil {
+		return false, fmt.Errorf("price or 200-day average not available: %w", ErrNoData)
+	}
+	return s.Price.Raw > s.TwoHundredDayAverage.Raw, nil
+}
+
+// FetchTechnicalLevels retrieves the current price, 52-week range and 50/200-day moving averages of the ticker
+// in a single request, as a lightweight momentum check across a watchlist. The levels are read from
+// defaultKeyStatistics, supplemented by summaryDetail, and the current price, which neither carries,
+// from the small price module. ErrNoData is returned when Yahoo sends none of the levels.
+func (t *Ticker) FetchTechnicalLevels() (TechnicalLevels, error) {
+	result, err := t.fetchQuoteSummary("defaultKeyStatistics,summaryDetail,price")
+	if err != nil {
+		return TechnicalLevels{}, err
+	}
+
+	type levels struct {
+		FiftyTwoWeekLow      *PriceValue `json:"fiftyTwoWeekLow"`
+		FiftyTwoWeekHigh     *PriceValue `json:"fiftyTwoWeekHigh"`
+		FiftyDayAverage      *PriceValue `json:"fiftyDayAverage"`
+		TwoHundredDayAverage *PriceValue `json:"twoHundredDayAverage"`
+	}
+	var summary struct {
+		DefaultKeyStatistics levels `json:"defaultKeyStatistics"`
+		SummaryDetail        levels `json:"summaryDetail"`
+		Price                struct {
+			RegularMarketPrice *PriceValue `json:"regularMarketPrice"`
+		} `json:"price"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return TechnicalLevels{}, fmt.Errorf("failed to decode technical levels JSON response: %v", err)
+	}
+
+	// Prioritize defaultKeyStatistics, as extractFinancialSummary does
+	keyStatistics, detail := summary.DefaultKeyStatistics, summary.SummaryDetail
+	technical := TechnicalLevels{
+		Price:                summary.Price.RegularMarketPrice,
+		FiftyTwoWeekLow:      firstPriceValue(keyStatistics.FiftyTwoWeekLow, detail.FiftyTwoWeekLow),
+		FiftyTwoWeekHigh:     firstPriceValue(keyStatistics.FiftyTwoWeekHigh, detail.FiftyTwoWeekHigh),
+		FiftyDayAverage:      firstPriceValue(keyStatistics.FiftyDayAverage, detail.FiftyDayAverage),
+		TwoHundredDayAverage: firstPriceValue(keyStatistics.TwoHundredDayAverage, detail.TwoHundredDayAverage),
+	}
+	if technical.FiftyTwoWeekLow == nil && technical.FiftyTwoWeekHigh == nil &&
+		technical.FiftyDayAverage == nil && technical.TwoHundredDayAverage == nil {
+		return TechnicalLevels{}, fmt.Errorf("no technical levels found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+	if technical.Price != nil && technical.TwoHundredDayAverage != nil {
+		technical.AboveTwoHundredDayAverage = technical.Price.Raw > technical.TwoHundredDayAverage.Raw
+	}
+	return technical, nil
+}
+
+// firstPriceValue returns the first of values that is not nil
+func firstPriceValue(values ...*PriceValue) *PriceValue {
+	for _, value := range values {
+		if value != nil {
+			return value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package yfinance_api
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// TestDayRange tests reading the day range from the ticker info
+func TestDayRange(t *testing.T) {
+	info := YahooTickerInfo{
+		Symbol:               "AAPL",
+		RegularMarketDayLow:  &PriceValue{Raw: 187.5},
+		RegularMarketDayHigh: &PriceValue{Raw: 191.2},
+	}
+
+	low, high, err := info.DayRange()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if low != 187.5 || high != 191.2 {
+		t.Errorf("Expected 187.5-191.2, got %v-%v", low, high)
+	}
+
+	if _, _, err := (YahooTickerInfo{}).DayRange(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+// TestYearRange tests the 52-week range of the key statistics
+func TestYearRange(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"defaultKeyStatistics":{"beta":{"raw":1.2},
+		"fiftyTwoWeekLow":{"raw":164.08},"fiftyTwoWeekHigh":{"raw":199.62}}}`)
+
+	summary, err := ticker.FetchKeyStatistics()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	low, high, err := summary.YearRange()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if low != 164.08 || high != 199.62 {
+		t.Errorf("Expected 164.08-199.62, got %v-%v", low, high)
+	}
+
+	if _, _, err := (FinancialSummary{}).YearRange(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+// TestRangePosition tests the position of a price within a range
+func TestRangePosition(t *testing.T) {
+	testCases := []struct {
+		name      string
+		price     float64
+		low, high float64
+		expected  float64
+	}{
+		{name: "At low", price: 10, low: 10, high: 20, expected: 0},
+		{name: "Middle", price: 15, low: 10, high: 20, expected: 0.5},
+		{name: "At high", price: 20, low: 10, high: 20, expected: 1},
+		{name: "Above high", price: 25, low: 10, high: 20, expected: 1},
+		{name: "Below low", price: 5, low: 10, high: 20, expected: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if position := RangePosition(tc.price, tc.low, tc.high); position != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, position)
+			}
+		})
+	}
+
+	if position := RangePosition(10, 10, 10); !math.IsNaN(position) {
+		t.Errorf("Expected NaN for an empty range, got %v", position)
+	}
+}
+
+// TestFetchPriceStatistics tests reading the ranges from summaryDetail and the price from the price module
+func TestFetchPriceStatistics(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"summaryDetail":{"maxAge":1,
+		"regularMarketDayLow":{"raw":187.5},"regularMarketDayHigh":{"raw":191.2},
+		"fiftyTwoWeekLow":{"raw":164},"fiftyTwoWeekHigh":{"raw":200},
+		"fiftyDayAverage":{"raw":182.4},"twoHundredDayAverage":{"raw":178.9}},
+		"price":{"regularMarketPrice":{"raw":191}}}`)
+
+	statistics, err := ticker.FetchPriceStatistics()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if statistics.DayLow.Raw != 187.5 || statistics.DayHigh.Raw != 191.2 || statistics.TwoHundredDayAverage.Raw != 178.9 {
+		t.Errorf("Unexpected statistics: %+v", statistics)
+	}
+	position, err := statistics.PercentOf52WeekRange()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(position-0.75) > 1e-9 {
+		t.Errorf("Expected the price at 75%% of the 52-week range, got %v", position)
+	}
+
+	if above, err := statistics.AboveTwoHundredDayAverage(); err != nil || !above {
+		t.Errorf("Expected the price above its 200-day average, got %v (%v)", above, err)
+	}
+	statistics.FiftyDayAverage = &PriceValue{Raw: 195}
+	if above, err := statistics.AboveFiftyDayAverage(); err != nil || above {
+		t.Errorf("Expected the price below its 50-day average, got %v (%v)", above, err)
+	}
+
+	statistics.Price = nil
+	if _, err := statistics.PercentOf52WeekRange(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without a price, got %v", err)
+	}
+	if _, err := statistics.AboveTwoHundredDayAverage(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without a price, got %v", err)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "TINY", `{"price":{"regularMarketPrice":{"raw":1}}}`)
+	if _, err := ticker.FetchPriceStatistics(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without summaryDetail, got %v", err)
+	}
+}
+
+// TestFetchTechnicalLevels tests merging the levels of both modules and comparing the price to its 200-day average
+func TestFetchTechnicalLevels(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"defaultKeyStatistics":{"fiftyTwoWeekLow":{"raw":164}},
+		"summaryDetail":{"fiftyTwoWeekLow":{"raw":160},"fiftyTwoWeekHigh":{"raw":200},
+		"fiftyDayAverage":{"raw":182.4},"twoHundredDayAverage":{"raw":178.9}},
+		"price":{"regularMarketPrice":{"raw":191}}}`)
+
+	levels, err := ticker.FetchTechnicalLevels()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if levels.Price.Raw != 191 || levels.FiftyTwoWeekLow.Raw != 164 || levels.FiftyTwoWeekHigh.Raw != 200 ||
+		levels.FiftyDayAverage.Raw != 182.4 || levels.TwoHundredDayAverage.Raw != 178.9 {
+		t.Errorf("Unexpected levels: %+v", levels)
+	}
+	if !levels.AboveTwoHundredDayAverage {
+		t.Error("Expected the price above its 200-day average")
+	}
+
+	ticker = newQuoteSummaryTicker(t, "NOAVG", `{"summaryDetail":{"fiftyTwoWeekHigh":{"raw":200}},"price":{"regularMarketPrice":{"raw":191}}}`)
+	if levels, err := ticker.FetchTechnicalLevels(); err != nil || levels.AboveTwoHundredDayAverage {
+		t.Errorf("Expected false without a 200-day average, got %+v (%v)", levels, err)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "TINY", `{"price":{"regularMarketPrice":{"raw":1}}}`)
+	if _, err := ticker.FetchTechnicalLevels(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without levels, got %v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package yfinance_api
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter is a token bucket: it holds up to burst tokens, refilled at rate tokens per second,
+// and every request takes one. A nil *rateLimiter never waits.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a full bucket, or nil when requestsPerSecond isn't positive
+func newRateLimiter(requestsPerSecond float64, burst int) *rateLimiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: requestsPerSecond, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Wait blocks until a token is available or ctx is done. Tokens are reserved in call order,
+// so concurrent callers are spaced out instead of all waking up at once.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	current := time.Now()
+	l.tokens = min(l.burst, l.tokens+current.Sub(l.last).Seconds()*l.rate)
+	l.last = current
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Give the reserved token back so the cancelled call doesn't delay the others
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,67 @@
+package yfinance_api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// TestRateLimiter tests that the burst passes at once and later requests are paced at the rate
+func TestRateLimiter(t *testing.T) {
+	limiter := newRateLimiter(50, 3)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 15*time.Millisecond {
+		t.Errorf("Expected the burst to pass without waiting, took %v", elapsed)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 80*time.Millisecond {
+		t.Errorf("Expected 5 requests beyond the burst to take about 100ms at 50/s, took %v", elapsed)
+	}
+
+	if newRateLimiter(0, 10) != nil {
+		t.Error("Expected no limiter for a zero rate")
+	}
+	var unlimited *rateLimiter
+	if err := unlimited.Wait(context.Background()); err != nil {
+		t.Errorf("Expected a nil limiter never to wait, got %v", err)
+	}
+}
+
+// TestWithRateLimit tests that requests through the client wait on the limiter and respect the context
+func TestWithRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := newTestClient(WithRateLimit(1, 1))
+	resp, err := client.Get(server.URL, url.Values{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+
+	// The bucket is empty, so the next request would wait a second: the context deadline ends it first
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.GetWithContext(ctx, server.URL, url.Values{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded while waiting for the limiter, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected the context to end the wait early, took %v", elapsed)
+	}
+}
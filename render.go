@@ -0,0 +1,181 @@
+package yfinance_api
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Views supported by Render
+const (
+	ViewPrice      = "price"
+	ViewFinancials = "financials"
+	ViewDividends  = "dividends"
+	ViewHistory    = "history"
+)
+
+// RenderOptions tunes what Render fetches and prints
+type RenderOptions struct {
+	Client   *YFinanceAPI // client used for the request, defaults to NewClient()
+	Range    string       // history range, defaults to "1mo"
+	Interval string       // history interval, defaults to "1d"
+	Rows     int          // number of most recent history rows to print, defaults to 10
+}
+
+// Render fetches one view of a symbol and returns it as human-readable text with aligned columns.
+// The view is one of ViewPrice, ViewFinancials, ViewDividends or ViewHistory. It is meant as a building
+// block for small CLI tools that just want to print something sensible.
+func Render(symbol, view string, opts RenderOptions) (string, error) {
+	if opts.Client == nil {
+		opts.Client = NewClient()
+	}
+	ticker := opts.Client.InstantiateTicker(symbol)
+
+	switch view {
+	case ViewPrice:
+		info, err := ticker.FetchInformation()
+		if err != nil {
+			return "", err
+		}
+		return renderPrice(info), nil
+	case ViewFinancials:
+		data, err := ticker.FetchFinancialData()
+		if err != nil {
+			return "", err
+		}
+		return renderFinancials(symbol, data), nil
+	case ViewDividends:
+		dividend, err := ticker.FetchDividendInfo()
+		if err != nil {
+			return "", err
+		}
+		return renderDividends(symbol, dividend), nil
+	case ViewHistory:
+		if opts.Range == "" {
+			opts.Range = "1mo"
+		}
+		data, err := ticker.FetchHistoricalData(opts.Range, opts.Interval, "", "")
+		if err != nil {
+			return "", err
+		}
+		return renderHistory(symbol, data, opts.Rows), nil
+	default:
+		return "", fmt.Errorf("unknown view %q: expected one of %s, %s, %s, %s", view, ViewPrice, ViewFinancials, ViewDividends, ViewHistory)
+	}
+}
+
+// renderPrice formats the main quote fields as a two-column table
+func renderPrice(info YahooTickerInfo) string {
+	return renderFields([][2]string{
+		{"Symbol", info.Symbol},
+		{"Name", info.LongName},
+		{"Price", renderValue(info.RegularMarketPrice)},
+		{"Change", renderValue(info.RegularMarketChange)},
+		{"Change %", renderValue(info.RegularMarketChangePercent)},
+		{"Day Low", renderValue(info.RegularMarketDayLow)},
+		{"Day High", renderValue(info.RegularMarketDayHigh)},
+		{"Volume", renderValue(info.RegularMarketVolume)},
+		{"Market Cap", renderValue(info.MarketCap)},
+		{"Currency", info.Currency},
+		{"Market State", info.MarketState},
+	})
+}
+
+// renderFinancials formats the key ratios and latest statement lines as a two-column table
+func renderFinancials(symbol string, data FinancialData) string {
+	return renderFields([][2]string{
+		{"Symbol", symbol},
+		{"Currency", data.Currency},
+		{"Market Cap", renderValue(data.Summary.MarketCap)},
+		{"P/E", renderValue(data.Ratios.PriceToEarningsRatio)},
+		{"P/B", renderValue(data.Ratios.PriceToBookRatio)},
+		{"ROE", renderValue(data.Ratios.ReturnOnEquity)},
+		{"Profit Margin", renderValue(data.Ratios.ProfitMargins)},
+		{"Debt/Equity", renderValue(data.Ratios.DebtToEquity)},
+		{"Revenue", renderValue(data.IncomeStatement.TotalRevenue)},
+		{"Net Income", renderValue(data.IncomeStatement.NetIncome)},
+		{"Total Assets", renderValue(data.BalanceSheet.TotalAssets)},
+		{"Total Debt", renderValue(data.BalanceSheet.TotalDebt)},
+		{"Operating Cash Flow", renderValue(data.CashFlow.OperatingCashFlow)},
+		{"Free Cash Flow", renderValue(data.CashFlow.FreeCashFlow)},
+	})
+}
+
+// renderDividends formats the dividend information as a two-column table
+func renderDividends(symbol string, dividend DividendInfo) string {
+	return renderFields([][2]string{
+		{"Symbol", symbol},
+		{"Dividend Rate", renderValue(dividend.DividendRate)},
+		{"Dividend Yield", renderValue(dividend.DividendYield)},
+		{"Payout Ratio", renderValue(dividend.PayoutRatio)},
+		{"Ex-Dividend Date", renderValue(dividend.ExDividendDate)},
+		{"Dividend Date", renderValue(dividend.DividendDate)},
+		{"5Y Avg Yield", renderValue(dividend.FiveYearAvgDividendYield)},
+	})
+}
+
+// renderHistory formats the most recent rows of historical data as an OHLCV table in date order
+func renderHistory(symbol string, data map[string]PriceData, rows int) string {
+	if rows <= 0 {
+		rows = 10
+	}
+
+	dates := make([]string, 0, len(data))
+	for date := range data {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	if len(dates) > rows {
+		dates = dates[len(dates)-rows:]
+	}
+
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "%s\tOpen\tHigh\tLow\tClose\tVolume\t\n", symbol)
+	for _, date := range dates {
+		price := data[date]
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t\n", date,
+			renderFloat(price.Open), renderFloat(price.High), renderFloat(price.Low), renderFloat(price.Close),
+			renderInt(price.Volume))
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+// renderFields formats label/value pairs as two aligned columns
+func renderFields(fields [][2]string) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, field := range fields {
+		value := field[1]
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", field[0], value)
+	}
+	_ = w.Flush()
+	return buf.String()
+}
+
+func renderValue(p *PriceValue) string {
+	if p == nil {
+		return "-"
+	}
+	return p.String()
+}
+
+func renderFloat(f *float64) string {
+	if f == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*f, 'f', 2, 64)
+}
+
+func renderInt(i *int64) string {
+	if i == nil {
+		return "-"
+	}
+	return strconv.FormatInt(*i, 10)
+}
@@ -0,0 +1,65 @@
+package yfinance_api
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRenderPrice tests the aligned two-column price view
+func TestRenderPrice(t *testing.T) {
+	output := renderPrice(YahooTickerInfo{
+		Symbol:             "AAPL",
+		LongName:           "Apple Inc.",
+		RegularMarketPrice: &PriceValue{Raw: 189.84, Fmt: "189.84"},
+		Currency:           "USD",
+	})
+
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+	if !strings.HasPrefix(lines[0], "Symbol") || !strings.HasSuffix(lines[0], "AAPL") {
+		t.Errorf("Unexpected first line: %q", lines[0])
+	}
+
+	// Values must start in the same column on every line
+	column := strings.Index(lines[0], "AAPL")
+	for _, line := range lines {
+		if len(line) <= column || line[column-1] != ' ' || line[column] == ' ' {
+			t.Errorf("Line is not aligned on column %d: %q", column, line)
+		}
+	}
+
+	if !strings.Contains(output, "189.84") {
+		t.Error("Expected the price in the output")
+	}
+	if !strings.Contains(output, "Market Cap") || !strings.Contains(lines[8], "-") {
+		t.Error("Expected missing values to be rendered as '-'")
+	}
+}
+
+// TestRenderHistory tests that history keeps the most recent rows in date order
+func TestRenderHistory(t *testing.T) {
+	data := map[string]PriceData{
+		"2024-01-03": {Close: floatPtr(3)},
+		"2024-01-01": {Close: floatPtr(1)},
+		"2024-01-02": {Close: floatPtr(2), Volume: int64Ptr(1000)},
+	}
+
+	output := renderHistory("AAPL", data, 2)
+	lines := strings.Split(strings.TrimRight(output, "\n"), "\n")
+
+	if len(lines) != 3 {
+		t.Fatalf("Expected a header and 2 rows, got %d lines:\n%s", len(lines), output)
+	}
+	if !strings.Contains(lines[1], "2024-01-02") || !strings.Contains(lines[1], "1000") {
+		t.Errorf("Unexpected first row: %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "2024-01-03") || !strings.Contains(lines[2], "3.00") {
+		t.Errorf("Unexpected last row: %q", lines[2])
+	}
+}
+
+// TestRenderUnknownView tests that unknown views are rejected before any request
+func TestRenderUnknownView(t *testing.T) {
+	if _, err := Render("AAPL", "chart", RenderOptions{}); err == nil {
+		t.Error("Expected an error for an unknown view")
+	}
+}
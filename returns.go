@@ -0,0 +1,160 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// daysPerYear is the average length of a year, used to annualize returns
+const daysPerYear = 365.25
+
+// tradingDaysPerYear is the usual number of trading days in a year, used to annualize daily volatility
+const tradingDaysPerYear = 252
+
+// PeriodReturn returns the simple return between the first and the last close of candles, which must be
+// in chronological order, as a fraction: (last - first) / first. Adjusted closes are used when both ends
+// have one, so that splits and dividends don't distort the return. Candles without a close are skipped,
+// and ErrInvalidParameter is returned when fewer than two remain or the first close is not positive.
+func PeriodReturn(candles []Candle) (float64, error) {
+	first, last, err := returnEnds(candles)
+	if err != nil {
+		return 0, err
+	}
+	return (last.price - first.price) / first.price, nil
+}
+
+// AnnualizedReturn returns the PeriodReturn of candles compounded over a year: (1 + r)^(365.25 / days) - 1,
+// where days is the time elapsed between the first and the last close. ErrInvalidParameter is returned
+// in the same cases as PeriodReturn, and when both closes share the same time.
+func AnnualizedReturn(candles []Candle) (float64, error) {
+	first, last, err := returnEnds(candles)
+	if err != nil {
+		return 0, err
+	}
+
+	days := last.time.Sub(first.time).Hours() / 24
+	if days <= 0 {
+		return 0, fmt.Errorf("cannot annualize a return over no time: %w", ErrInvalidParameter)
+	}
+	return math.Pow(last.price/first.price, daysPerYear/days) - 1, nil
+}
+
+// Volatility returns the annualized volatility of candles, which must be daily and in chronological order:
+// the sample standard deviation of the daily log returns between consecutive closes, scaled by the square
+// root of 252 trading days. Adjusted closes are used when every candle with a close has one, so dividends
+// and splits are not taken for price moves. Candles without a close are skipped, so a return spans any gap.
+// It returns NaN when there are fewer than two returns or a close is not positive.
+func Volatility(candles []Candle) float64 {
+	adjusted := allAdjusted(candles)
+
+	var logReturns []float64
+	previous := 0.0
+	for _, candle := range candles {
+		if candle.Close == nil {
+			continue
+		}
+		price := *candle.Close
+		if adjusted {
+			price = *candle.AdjClose
+		}
+		if price <= 0 {
+			return math.NaN()
+		}
+		if previous > 0 {
+			logReturns = append(logReturns, math.Log(price/previous))
+		}
+		previous = price
+	}
+	if len(logReturns) < 2 {
+		return math.NaN()
+	}
+
+	mean := 0.0
+	for _, r := range logReturns {
+		mean += r
+	}
+	mean /= float64(len(logReturns))
+	variance := 0.0
+	for _, r := range logReturns {
+		variance += (r - mean) * (r - mean)
+	}
+	variance /= float64(len(logReturns) - 1)
+	return math.Sqrt(variance * tradingDaysPerYear)
+}
+
+// MaxDrawdown returns the largest peak-to-trough decline of the closes of candles, which must be in
+// chronological order, as a positive fraction of the peak, along with the times of that peak and trough.
+// Adjusted closes are used when every candle with a close has one. Candles without a close are skipped,
+// and a series that never declines, or has no positive close, gives 0 and zero times.
+func MaxDrawdown(candles []Candle) (drawdown float64, peak, trough time.Time) {
+	adjusted := allAdjusted(candles)
+
+	var runningPeak returnPoint
+	for _, candle := range candles {
+		if candle.Close == nil {
+			continue
+		}
+		point := returnPoint{time: candle.Time, price: *candle.Close}
+		if adjusted {
+			point.price = *candle.AdjClose
+		}
+
+		if point.price > runningPeak.price {
+			runningPeak = point
+			continue
+		}
+		if runningPeak.price <= 0 {
+			continue
+		}
+		if decline := (runningPeak.price - point.price) / runningPeak.price; decline > drawdown {
+			drawdown, peak, trough = decline, runningPeak.time, point.time
+		}
+	}
+	return drawdown, peak, trough
+}
+
+// returnPoint is a close used as one end of a return
+type returnPoint struct {
+	time  time.Time
+	price float64
+}
+
+// returnEnds finds the first and last candles with a close, preferring their adjusted closes when both have one
+func returnEnds(candles []Candle) (first, last returnPoint, err error) {
+	firstIndex, lastIndex := -1, -1
+	for i, candle := range candles {
+		if candle.Close == nil {
+			continue
+		}
+		if firstIndex < 0 {
+			firstIndex = i
+		}
+		lastIndex = i
+	}
+	if firstIndex < 0 || firstIndex == lastIndex {
+		return returnPoint{}, returnPoint{}, fmt.Errorf("at least two closes are needed to compute a return: %w", ErrInvalidParameter)
+	}
+
+	firstCandle, lastCandle := candles[firstIndex], candles[lastIndex]
+	first = returnPoint{time: firstCandle.Time, price: *firstCandle.Close}
+	last = returnPoint{time: lastCandle.Time, price: *lastCandle.Close}
+	if firstCandle.AdjClose != nil && lastCandle.AdjClose != nil {
+		first.price, last.price = *firstCandle.AdjClose, *lastCandle.AdjClose
+	}
+
+	if first.price <= 0 {
+		return returnPoint{}, returnPoint{}, fmt.Errorf("cannot compute a return from a close of %v: %w", first.price, ErrInvalidParameter)
+	}
+	return first, last, nil
+}
+
+// allAdjusted reports whether every candle with a close also has an adjusted close
+func allAdjusted(candles []Candle) bool {
+	for _, candle := range candles {
+		if candle.Close != nil && candle.AdjClose == nil {
+			return false
+		}
+	}
+	return true
+}
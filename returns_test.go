@@ -0,0 +1,151 @@
+package yfinance_api
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestPeriodReturn tests the simple and annualized returns, preferring adjusted closes
+func TestPeriodReturn(t *testing.T) {
+	start := time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC)
+	candles := []Candle{
+		{Time: start.AddDate(0, 0, -1)},
+		{Time: start, Close: floatPtr(100)},
+		{Time: start.AddDate(0, 6, 0), Close: floatPtr(90)},
+		{Time: start.Add(time.Duration(2*daysPerYear*24) * time.Hour), Close: floatPtr(121)},
+		{Time: start.AddDate(3, 0, 0)},
+	}
+
+	periodReturn, err := PeriodReturn(candles)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(periodReturn-0.21) > 1e-9 {
+		t.Errorf("Expected a return of 0.21, got %v", periodReturn)
+	}
+	annualized, err := AnnualizedReturn(candles)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(annualized-0.1) > 1e-9 {
+		t.Errorf("Expected an annualized return of 0.1 over two years, got %v", annualized)
+	}
+
+	// Adjusted closes are only used when both ends have one
+	candles[1].AdjClose = floatPtr(80)
+	if periodReturn, _ := PeriodReturn(candles); math.Abs(periodReturn-0.21) > 1e-9 {
+		t.Errorf("Expected the closes with a single adjusted end, got %v", periodReturn)
+	}
+	candles[3].AdjClose = floatPtr(100)
+	if periodReturn, _ := PeriodReturn(candles); math.Abs(periodReturn-0.25) > 1e-9 {
+		t.Errorf("Expected the adjusted return of 0.25, got %v", periodReturn)
+	}
+}
+
+// TestPeriodReturnDegenerate tests the inputs a return can't be computed from
+func TestPeriodReturnDegenerate(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	testCases := map[string][]Candle{
+		"No candles":      nil,
+		"Single close":    {{Time: day, Close: floatPtr(10)}, {Time: day.AddDate(0, 0, 1)}},
+		"Zero first":      {{Time: day, Close: floatPtr(0)}, {Time: day.AddDate(0, 0, 1), Close: floatPtr(10)}},
+		"No elapsed time": {{Time: day, Close: floatPtr(10)}, {Time: day, Close: floatPtr(11)}},
+	}
+
+	for name, candles := range testCases {
+		_, annualizedErr := AnnualizedReturn(candles)
+		if !errors.Is(annualizedErr, ErrInvalidParameter) {
+			t.Errorf("%s: expected ErrInvalidParameter from AnnualizedReturn, got %v", name, annualizedErr)
+		}
+		if _, err := PeriodReturn(candles); name != "No elapsed time" && !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("%s: expected ErrInvalidParameter from PeriodReturn, got %v", name, err)
+		}
+	}
+}
+
+// TestVolatility tests the annualized volatility of daily log returns, across a candle without a close and on adjusted closes
+func TestVolatility(t *testing.T) {
+	e := math.E
+	candles := closeCandles(floatPtr(1), floatPtr(e), nil, floatPtr(1), floatPtr(e))
+
+	// Log returns of 1, -1 and 1 have a sample standard deviation of sqrt(4/3)
+	if got, expected := Volatility(candles), math.Sqrt(4.0/3*252); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("Expected a volatility of %v, got %v", expected, got)
+	}
+	if got := Volatility(closeCandles(floatPtr(5), floatPtr(5), floatPtr(5))); got != 0 {
+		t.Errorf("Expected no volatility for flat closes, got %v", got)
+	}
+	for name, candles := range map[string][]Candle{
+		"Single return": closeCandles(floatPtr(1), nil, floatPtr(2)),
+		"Zero close":    closeCandles(floatPtr(1), floatPtr(0), floatPtr(2)),
+	} {
+		if got := Volatility(candles); !math.IsNaN(got) {
+			t.Errorf("%s: expected NaN, got %v", name, got)
+		}
+	}
+
+	// A split halving the raw close is no move once adjusted, unless a candle lacks its adjusted close
+	split := closeCandles(floatPtr(100), floatPtr(50), floatPtr(50))
+	for i := range split {
+		split[i].AdjClose = floatPtr(50)
+	}
+	if got := Volatility(split); got != 0 {
+		t.Errorf("Expected no volatility on adjusted closes, got %v", got)
+	}
+	split[2].AdjClose = nil
+	if got := Volatility(split); got == 0 || math.IsNaN(got) {
+		t.Errorf("Expected the raw closes to be used, got %v", got)
+	}
+}
+
+// TestMaxDrawdown tests that the largest decline from a running peak is found, preferring adjusted closes
+func TestMaxDrawdown(t *testing.T) {
+	day := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	closes := []*float64{floatPtr(100), floatPtr(120), floatPtr(90), nil, floatPtr(110), floatPtr(130), floatPtr(104), floatPtr(140)}
+	candles := closeCandles(closes...)
+	for i := range candles {
+		candles[i].Time = day.AddDate(0, 0, i)
+	}
+
+	drawdown, peak, trough := MaxDrawdown(candles)
+	if math.Abs(drawdown-0.25) > 1e-9 || !peak.Equal(day.AddDate(0, 0, 1)) || !trough.Equal(day.AddDate(0, 0, 2)) {
+		t.Errorf("Expected a drawdown of 0.25 from day 1 to day 2, got %v from %v to %v", drawdown, peak, trough)
+	}
+
+	// Adjusted closes are only used when every close has one
+	candles[2].AdjClose = floatPtr(120)
+	if drawdown, _, _ := MaxDrawdown(candles); math.Abs(drawdown-0.25) > 1e-9 {
+		t.Errorf("Expected the closes with partial adjusted closes, got %v", drawdown)
+	}
+	for i, price := range closes {
+		if price != nil && i != 2 {
+			candles[i].AdjClose = price
+		}
+	}
+	if drawdown, _, trough := MaxDrawdown(candles); math.Abs(drawdown-0.2) > 1e-9 || !trough.Equal(day.AddDate(0, 0, 6)) {
+		t.Errorf("Expected the adjusted drawdown of 0.2 on day 6, got %v on %v", drawdown, trough)
+	}
+
+	drawdown, peak, trough = MaxDrawdown(closeCandles(floatPtr(1), floatPtr(2), floatPtr(2), floatPtr(3)))
+	if drawdown != 0 || !peak.IsZero() || !trough.IsZero() {
+		t.Errorf("Expected no drawdown for a rising series, got %v from %v to %v", drawdown, peak, trough)
+	}
+}
+
+// TestFetchCandlesAdjClose tests that the adjusted closes of daily charts are decoded
+func TestFetchCandlesAdjClose(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","dataGranularity":"1d"},
+		"timestamp":[1704205800,1704292200],
+		"indicators":{"quote":[{"close":[185.64,184.25]}],"adjclose":[{"adjclose":[184.94,null]}]}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	candles, err := ticker.FetchCandles("5d", "1d")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(candles) != 2 || candles[0].AdjClose == nil || *candles[0].AdjClose != 184.94 || candles[1].AdjClose != nil {
+		t.Errorf("Unexpected adjusted closes in %+v", candles)
+	}
+}
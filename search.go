@@ -0,0 +1,110 @@
+package yfinance_api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SearchResult is one instrument matched by the search endpoint
+type SearchResult struct {
+	Symbol    string  `json:"symbol"`
+	ShortName string  `json:"shortname"`
+	LongName  string  `json:"longname"`
+	QuoteType string  `json:"quoteType"` // EQUITY, ETF, MUTUALFUND, INDEX, CURRENCY, CRYPTOCURRENCY...
+	Exchange  string  `json:"exchange"`
+	Sector    string  `json:"sector"`
+	Industry  string  `json:"industry"`
+	Score     float64 `json:"score"` // Yahoo's relevance score, higher is better
+}
+
+// search queries the search endpoint and returns up to count matching instruments, best match first
+func (c *Client) search(ctx context.Context, query string, count int) ([]SearchResult, error) {
+	params := url.Values{}
+	params.Add("q", query)
+	params.Add("quotesCount", strconv.Itoa(count))
+	params.Add("newsCount", "0")
+
+	endpoint := fmt.Sprintf("%s/v1/finance/search", c.baseURL())
+
+	resp, err := c.GetWithContext(ctx, endpoint, params)
+	if err != nil {
+		c.log().Error("Failed to search", "query", query, "err", err)
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			c.log().Error("Failed to close response body", "err", err)
+		}
+	}(resp.Body)
+
+	var searchResponse struct {
+		Quotes []SearchResult `json:"quotes"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&searchResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode search JSON response: %v", err)
+	}
+
+	return searchResponse.Quotes, nil
+}
+
+// Search looks up the instruments matching a company name or partial symbol, best match first.
+// At most limit results are returned, 10 when limit isn't positive. An empty query returns an error
+// matching ErrInvalidParameter, while a query without any match returns an empty slice.
+func (c *YFinanceAPI) Search(query string, limit int) ([]SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("search query must not be empty: %w", ErrInvalidParameter)
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	results, err := c.Client.search(context.Background(), query, limit)
+	if err != nil {
+		return nil, err
+	}
+	if results == nil {
+		results = []SearchResult{}
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// ResolveNames looks up the best matching instrument for each company name concurrently, keyed by name.
+// The quote type of each match is included so callers can filter out non-equities.
+// Names without any match are left out of the result and reported, together with failed requests,
+// through a *BatchError whose entries wrap ErrNoData for unresolved names.
+func (c *YFinanceAPI) ResolveNames(names []string) (map[string]SearchResult, error) {
+	// Bootstrap the crumb once up front rather than from every goroutine
+	c.Client.getCrumb(context.Background())
+
+	var mu sync.Mutex
+	matches := make(map[string]SearchResult, len(names))
+
+	err := forEachConcurrent(names, DefaultConcurrency, func(name string) error {
+		results, err := c.Client.search(context.Background(), name, 1)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			return fmt.Errorf("no symbol found for name %q: %w", name, ErrNoData)
+		}
+
+		mu.Lock()
+		matches[name] = results[0]
+		mu.Unlock()
+		return nil
+	})
+
+	return matches, err
+}
@@ -0,0 +1,85 @@
+package yfinance_api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestResolveNames tests resolving names concurrently and reporting unresolved ones
+func TestResolveNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("q") {
+		case "Apple":
+			fmt.Fprint(w, `{"quotes":[{"symbol":"AAPL","shortname":"Apple Inc.","quoteType":"EQUITY","exchange":"NMS"}]}`)
+		case "Vanguard S&P 500":
+			fmt.Fprint(w, `{"quotes":[{"symbol":"VOO","shortname":"Vanguard S&P 500 ETF","quoteType":"ETF","exchange":"PCX"}]}`)
+		default:
+			fmt.Fprint(w, `{"quotes":[]}`)
+		}
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	client := &YFinanceAPI{Client: newTestClient()}
+	matches, err := client.ResolveNames([]string{"Apple", "Vanguard S&P 500", "Not A Company"})
+
+	if matches["Apple"].Symbol != "AAPL" || matches["Apple"].QuoteType != "EQUITY" {
+		t.Errorf("Unexpected match for Apple: %+v", matches["Apple"])
+	}
+	if matches["Vanguard S&P 500"].QuoteType != "ETF" {
+		t.Errorf("Expected the ETF quote type, got %+v", matches["Vanguard S&P 500"])
+	}
+
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || len(batchErr.Errors) != 1 {
+		t.Fatalf("Expected a BatchError for the unresolved name, got %v", err)
+	}
+	if !errors.Is(batchErr.Errors["Not A Company"], ErrNoData) {
+		t.Errorf("Expected ErrNoData for the unresolved name, got %v", batchErr.Errors["Not A Company"])
+	}
+}
+
+// TestSearch tests the typed search results, the limit and the handling of empty queries and results
+func TestSearch(t *testing.T) {
+	var quotesCount string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		quotesCount = r.URL.Query().Get("quotesCount")
+		if r.URL.Query().Get("q") != "apple" {
+			fmt.Fprint(w, `{"quotes":[],"news":[]}`)
+			return
+		}
+		fmt.Fprint(w, `{"quotes":[
+			{"symbol":"AAPL","shortname":"Apple Inc.","longname":"Apple Inc.","quoteType":"EQUITY","exchange":"NMS","score":31283},
+			{"symbol":"APLE","shortname":"Apple Hospitality REIT, Inc.","quoteType":"EQUITY","exchange":"NYQ","score":20212}],
+			"news":[{"title":"ignored"}]}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	client := &YFinanceAPI{Client: newTestClient()}
+	results, err := client.Search(" apple ", 5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if quotesCount != "5" {
+		t.Errorf("Expected quotesCount 5, got %q", quotesCount)
+	}
+	if len(results) != 2 || results[0].Symbol != "AAPL" || results[0].LongName != "Apple Inc." || results[0].Score != 31283 {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+
+	results, err = client.Search("zzzz", 0)
+	if err != nil || results == nil || len(results) != 0 {
+		t.Errorf("Expected an empty slice without error, got %v, %v", results, err)
+	}
+	if quotesCount != "10" {
+		t.Errorf("Expected the default limit of 10, got %q", quotesCount)
+	}
+
+	if _, err := client.Search("  ", 5); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter for an empty query, got %v", err)
+	}
+}
@@ -0,0 +1,134 @@
+package yfinance_api
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// flightGroup coalesces concurrent identical requests: while a request for a key is in flight,
+// other callers for the same key wait for it and share its response instead of sending their own.
+// The zero value is ready to use.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a request in progress and, once done is closed, its outcome.
+// waiters and landed are guarded by the group's mutex.
+type flight struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int  // callers still waiting for the outcome
+	landed  bool // the outcome is being handed out, so waiters can no longer leave
+	resp    *http.Response
+	entry   cacheEntry
+	err     error
+}
+
+// do returns the response of fetch for key, joining the call already in flight for key if any.
+// fetch runs on a context detached from any single caller, carrying the values of the first caller's ctx,
+// so a caller whose ctx ends returns ctx.Err() while the others keep waiting; the fetch itself is only
+// cancelled once every caller has left. The response is buffered when buffer is true or several callers
+// share it, each caller getting its own copy, and streamed to the caller otherwise.
+// store, when not nil, receives the buffered response.
+func (g *flightGroup) do(ctx context.Context, key string, buffer bool, fetch func(context.Context) (*http.Response, error), store func(cacheEntry)) (*http.Response, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	current, ok := g.flights[key]
+	if !ok {
+		flightCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		current = &flight{done: make(chan struct{}), cancel: cancel}
+		g.flights[key] = current
+		go g.run(flightCtx, key, current, buffer, fetch, store)
+	}
+	current.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-current.done:
+		return current.response()
+	case <-ctx.Done():
+	}
+
+	g.mu.Lock()
+	if current.landed {
+		// Too late to leave: the outcome is already ours, so release it
+		g.mu.Unlock()
+		<-current.done
+		if resp, err := current.response(); err == nil {
+			_ = resp.Body.Close()
+		}
+		return nil, ctx.Err()
+	}
+	current.waiters--
+	if current.waiters == 0 {
+		// Nobody is interested anymore: abandon the request, and let the next caller start a new one
+		current.cancel()
+		if g.flights[key] == current {
+			delete(g.flights, key)
+		}
+	}
+	g.mu.Unlock()
+	return nil, ctx.Err()
+}
+
+// run performs fetch for the flight and hands its outcome to the callers still waiting
+func (g *flightGroup) run(ctx context.Context, key string, current *flight, buffer bool, fetch func(context.Context) (*http.Response, error), store func(cacheEntry)) {
+	defer close(current.done)
+
+	resp, err := fetch(ctx)
+
+	g.mu.Lock()
+	if g.flights[key] == current {
+		delete(g.flights, key)
+	}
+	waiters := current.waiters
+	current.landed = waiters > 0
+	g.mu.Unlock()
+
+	switch {
+	case err != nil:
+		current.cancel()
+		current.err = err
+	case waiters == 0:
+		_ = resp.Body.Close()
+		current.cancel()
+	case buffer || waiters > 1:
+		current.entry, current.err = bufferResponse(resp)
+		current.cancel()
+		if current.err == nil && store != nil {
+			store(current.entry)
+		}
+	default:
+		// A single caller reads the body straight from the connection, which stays open until it closes the body
+		resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: current.cancel}
+		current.resp = resp
+	}
+}
+
+// response returns the outcome of the flight: the streamed response of its single caller, or a copy of the buffered one
+func (f *flight) response() (*http.Response, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.resp != nil {
+		return f.resp, nil
+	}
+	return f.entry.response(), nil
+}
+
+// cancelOnClose releases the context of a streamed response once its body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
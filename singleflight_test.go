@@ -0,0 +1,213 @@
+package yfinance_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestCoalesceConcurrentRequests tests that concurrent identical requests share one round trip
+// while requests with other parameters get their own
+func TestCoalesceConcurrentRequests(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(100 * time.Millisecond)
+		fmt.Fprintf(w, `{"symbols":%q}`, r.URL.Query().Get("symbols"))
+	}))
+	defer server.Close()
+
+	client := newTestClient()
+	var wg sync.WaitGroup
+	bodies := make([]string, 10)
+	errs := make([]error, 10)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			symbols := "AAPL"
+			if i == 0 {
+				symbols = "MSFT"
+			}
+			resp, err := client.Get(server.URL+"/v7/finance/quote", url.Values{"symbols": {symbols}})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			bodies[i], errs[i] = string(body), err
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Request %d failed: %v", i, err)
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected one request per distinct query, got %d", got)
+	}
+	if bodies[0] != `{"symbols":"MSFT"}` {
+		t.Errorf("Unexpected MSFT body %q", bodies[0])
+	}
+	for i := 1; i < len(bodies); i++ {
+		if bodies[i] != `{"symbols":"AAPL"}` {
+			t.Errorf("Expected every AAPL caller to read the full body, got %q", bodies[i])
+		}
+	}
+
+	// Once the first round trip is over, the next call reaches the server again
+	resp, err := client.Get(server.URL+"/v7/finance/quote", url.Values{"symbols": {"AAPL"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if got := requests.Load(); got != 3 {
+		t.Errorf("Expected a new request after the shared one completed, got %d requests", got)
+	}
+}
+
+// TestCoalescedErrorPerCaller tests that callers sharing a failed round trip each get their own symbol error
+func TestCoalescedErrorPerCaller(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"chart":{"result":null,"error":{"code":"Not Found","description":"No data found"}}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	api := &YFinanceAPI{Client: newTestClient(WithRetryPolicy(nil))}
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = api.InstantiateTicker("NOPE").FetchHistoricalData("1mo", "1d", "", "")
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		var symbolErr *SymbolError
+		if !errors.As(err, &symbolErr) || symbolErr.Symbol != "NOPE" || !errors.Is(err, ErrSymbolNotFound) {
+			t.Errorf("Caller %d: expected a SymbolError for NOPE, got %v", i, err)
+		}
+	}
+}
+
+// TestCoalescedCallerCancel tests that a caller giving up doesn't fail the others sharing its round trip,
+// and that the round trip is abandoned once every caller has given up
+func TestCoalescedCallerCancel(t *testing.T) {
+	var requests atomic.Int32
+	aborted := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case <-time.After(100 * time.Millisecond):
+			fmt.Fprint(w, `{"ok":true}`)
+		case <-r.Context().Done():
+			aborted <- struct{}{}
+		}
+	}))
+	defer server.Close()
+
+	client := newTestClient(WithRetryPolicy(nil))
+	endpoint := server.URL + "/v7/finance/quote"
+	first, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := client.GetWithContext(first, endpoint, url.Values{"symbols": {"AAPL"}})
+		firstErr <- err
+	}()
+	// Let the first caller start the round trip before the others join it
+	time.Sleep(20 * time.Millisecond)
+
+	var wg sync.WaitGroup
+	bodies := make([]string, 5)
+	errs := make([]error, 5)
+	for i := range bodies {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			resp, err := client.GetWithContext(context.Background(), endpoint, url.Values{"symbols": {"AAPL"}})
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			bodies[i], errs[i] = string(body), err
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	cancelFirst()
+	wg.Wait()
+
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancelled caller to get context.Canceled, got %v", err)
+	}
+	for i, err := range errs {
+		if err != nil || bodies[i] != `{"ok":true}` {
+			t.Errorf("Caller %d: expected the shared response, got %q (%v)", i, bodies[i], err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected a single round trip, got %d", got)
+	}
+
+	// A lone caller giving up abandons the round trip
+	lone, cancelLone := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelLone()
+	if _, err := client.GetWithContext(lone, endpoint, url.Values{"symbols": {"MSFT"}}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Error("Expected the abandoned round trip to be cancelled")
+	}
+}
+
+// TestSingleCallerStreams tests that a call nobody shares hands the body over before it is fully received
+func TestSingleCallerStreams(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"first":1,`)
+		w.(http.Flusher).Flush()
+		<-release
+		fmt.Fprint(w, `"second":2}`)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := newTestClient()
+	got := make(chan *http.Response, 1)
+	go func() {
+		resp, err := client.Get(server.URL+"/v7/finance/quote", url.Values{"symbols": {"AAPL"}})
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		got <- resp
+	}()
+
+	select {
+	case resp := <-got:
+		if resp != nil {
+			resp.Body.Close()
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the response before the body was complete")
+	}
+}
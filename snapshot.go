@@ -0,0 +1,57 @@
+package yfinance_api
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// snapshotModules are the quoteSummary modules requested by FetchSnapshot
+const snapshotModules = "price,summaryDetail,defaultKeyStatistics,financialData,assetProfile,calendarEvents"
+
+// TickerSnapshot gathers the price, ratios, key statistics, dividend, profile and calendar of a ticker,
+// as returned by FetchInformation, FetchFinancialRatios, FetchKeyStatistics, FetchDividendInfo,
+// FetchCompanyProfile and FetchCalendar, from a single request
+type TickerSnapshot struct {
+	Info     YahooTickerInfo  `json:"info"`
+	Ratios   FinancialRatios  `json:"ratios"`
+	Summary  FinancialSummary `json:"summary"`
+	Dividend DividendInfo     `json:"dividend"` // DividendsPaid is left nil, as it comes from the cash flow statements
+	Profile  CompanyProfile   `json:"profile"`  // Zero for funds and indices, which have no company profile
+	Calendar Calendar         `json:"calendar"`
+}
+
+// FetchSnapshot retrieves a TickerSnapshot of the ticker with one quoteSummary request, instead of the
+// round trip per part that calling each Fetch method costs. Modules Yahoo omits leave their part zero.
+func (t *Ticker) FetchSnapshot() (TickerSnapshot, error) {
+	raw, err := t.fetchQuoteSummary(snapshotModules)
+	if err != nil {
+		return TickerSnapshot{}, err
+	}
+
+	var modules struct {
+		Price          *YahooTickerInfo     `json:"price"`
+		CalendarEvents *yahooCalendarEvents `json:"calendarEvents"`
+	}
+	var financial YahooFinancialResult
+	var dividend yahooDividendResult
+	for _, target := range []interface{}{&modules, &financial, &dividend} {
+		if err := json.Unmarshal(raw, target); err != nil {
+			return TickerSnapshot{}, fmt.Errorf("failed to decode snapshot JSON response: %v", err)
+		}
+	}
+
+	snapshot := TickerSnapshot{
+		Ratios:   t.extractFinancialRatios(financial),
+		Summary:  t.extractFinancialSummary(financial),
+		Dividend: t.extractDividendInfo(dividend),
+		Profile:  t.extractCompanyProfile(financial),
+	}
+	if modules.Price != nil {
+		snapshot.Info = *modules.Price
+	}
+	if modules.CalendarEvents != nil {
+		snapshot.Calendar = extractCalendar(*modules.CalendarEvents)
+	}
+
+	return snapshot, nil
+}
@@ -0,0 +1,74 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFetchSnapshot tests that every part of the snapshot is filled in from a single quoteSummary request
+func TestFetchSnapshot(t *testing.T) {
+	setNow(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if modules := r.URL.Query().Get("modules"); modules != snapshotModules {
+			t.Errorf("Expected modules %q, got %q", snapshotModules, modules)
+		}
+		fmt.Fprint(w, `{"quoteSummary":{"result":[{
+			"price":{"symbol":"KO","regularMarketPrice":{"raw":60.5,"fmt":"60.50"}},
+			"summaryDetail":{"trailingPE":{"raw":24.1},"dividendRate":{"raw":1.94},"dividendYield":{"raw":0.032}},
+			"defaultKeyStatistics":{"beta":{"raw":0.6}},
+			"financialData":{"returnOnEquity":{"raw":0.4}},
+			"assetProfile":{"sector":"Consumer Defensive","industry":"Beverages—Non-Alcoholic"},
+			"calendarEvents":{"earnings":{"earningsDate":[{"raw":1721736000}]}}
+		}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("KO")
+	snapshot, err := ticker.FetchSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected a single request, got %d", got)
+	}
+
+	if snapshot.Info.RegularMarketPrice == nil || snapshot.Info.RegularMarketPrice.Raw != 60.5 {
+		t.Errorf("Expected price 60.5, got %+v", snapshot.Info.RegularMarketPrice)
+	}
+	if snapshot.Ratios.PriceToEarningsRatio == nil || snapshot.Ratios.PriceToEarningsRatio.Raw != 24.1 {
+		t.Errorf("Expected P/E 24.1, got %+v", snapshot.Ratios.PriceToEarningsRatio)
+	}
+	if snapshot.Summary.Beta == nil || snapshot.Summary.Beta.Raw != 0.6 {
+		t.Errorf("Expected beta 0.6, got %+v", snapshot.Summary.Beta)
+	}
+	if rate, err := snapshot.Dividend.Rate(); err != nil || rate != 1.94 {
+		t.Errorf("Expected dividend rate 1.94, got %v (%v)", rate, err)
+	}
+	if snapshot.Profile.Sector != "Consumer Defensive" {
+		t.Errorf("Expected sector Consumer Defensive, got %q", snapshot.Profile.Sector)
+	}
+	if want := time.Unix(1721736000, 0).UTC(); !snapshot.Calendar.NextEarningsDate.Equal(want) {
+		t.Errorf("Expected next earnings on %v, got %v", want, snapshot.Calendar.NextEarningsDate)
+	}
+}
+
+// TestFetchSnapshotMissingModules tests that modules Yahoo omits leave their part zero instead of failing
+func TestFetchSnapshotMissingModules(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "^GSPC", `{"price":{"symbol":"^GSPC","regularMarketPrice":{"raw":5300}}}`)
+
+	snapshot, err := ticker.FetchSnapshot()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if snapshot.Info.Symbol != "^GSPC" || snapshot.Profile.Sector != "" || snapshot.Dividend.IsPaying() {
+		t.Errorf("Expected only the price to be set, got %+v", snapshot)
+	}
+}
@@ -1,16 +1,22 @@
 package yfinance_api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log/slog"
+	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"time"
 )
 
 type Ticker struct {
 	Symbol string
 	Client *Client
+	ctx    context.Context
 }
 
 // InstantiateTicker creates a new Ticker instance with the provided symbol and exchange name.
@@ -24,6 +30,38 @@ func (c *YFinanceAPI) InstantiateTicker(symbol string) *Ticker {
 	return ticker
 }
 
+// WithContext returns a copy of the ticker whose requests are bound to ctx, leaving the original untouched.
+// Use it to cancel a fetch or to give a single call a tighter deadline than the client timeout:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+//	defer cancel()
+//	info, err := ticker.WithContext(ctx).FetchInformation()
+func (t *Ticker) WithContext(ctx context.Context) *Ticker {
+	ticker := *t
+	ticker.ctx = ctx
+	return &ticker
+}
+
+// requestContext returns the context set with WithContext, or the background context
+func (t *Ticker) requestContext() context.Context {
+	if t.ctx == nil {
+		return context.Background()
+	}
+	return t.ctx
+}
+
+// get performs a request with the ticker's context, wrapping API errors in a *SymbolError for the ticker's symbol.
+// The *APIError itself may be shared with concurrent callers of the same request, so it is left untouched.
+func (t *Ticker) get(url string, params url.Values) (*http.Response, error) {
+	resp, err := t.Client.GetWithContext(t.requestContext(), url, params)
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return nil, &SymbolError{Symbol: t.Symbol, Err: err}
+	}
+	return resp, err
+}
+
 // GetSymbol returns the symbol of the Ticker instance.
 func (t *Ticker) GetSymbol() string {
 	return t.Symbol
@@ -44,18 +82,18 @@ func (t *Ticker) FetchInformation() (YahooTickerInfo, error) {
 	params.Add("modules", "price")
 
 	// Build the endpoint URL for the Yahoo Finance quoteSummary API
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BaseUrl, t.Symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
 
 	// Make the HTTP GET request using the client
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get ticker info", "err", err)
+		t.Client.log().Error("Failed to get ticker info", "err", err)
 		return YahooTickerInfo{}, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
@@ -73,7 +111,7 @@ func (t *Ticker) FetchInformation() (YahooTickerInfo, error) {
 
 	// Check if the result array is empty
 	if len(infoResponse.QuoteSummary.Result) == 0 {
-		return YahooTickerInfo{}, fmt.Errorf("no info found for symbol: %s", t.Symbol)
+		return YahooTickerInfo{}, emptyResultError("info", t.Symbol, infoResponse.QuoteSummary.Error)
 	}
 
 	// Return the ticker price information
@@ -87,7 +125,7 @@ func (t *Ticker) FetchInformation() (YahooTickerInfo, error) {
 func (t *Ticker) FetchPriceValue() (PriceValue, error) {
 	info, err := t.FetchInformation()
 	if err != nil {
-		slog.Error("Failed to fetch ticker price value", "err", err)
+		t.Client.log().Error("Failed to fetch ticker price value", "err", err)
 		return PriceValue{}, err
 	}
 
@@ -97,7 +135,22 @@ func (t *Ticker) FetchPriceValue() (PriceValue, error) {
 	}
 
 	// If RegularMarketPrice is not available, return an error
-	return PriceValue{}, fmt.Errorf("regular market price not available for symbol: %s", t.Symbol)
+	return PriceValue{}, fmt.Errorf("regular market price not available for symbol %s: %w", t.Symbol, ErrNoData)
+}
+
+// CurrencyInfo returns the ISO code (e.g. "EUR") and display symbol (e.g. "€") of the currency the ticker
+// is quoted in, from the price module. ErrNoData is returned when Yahoo doesn't report the currency.
+func (t *Ticker) CurrencyInfo() (isoCode, symbol string, err error) {
+	info, err := t.FetchInformation()
+	if err != nil {
+		return "", "", err
+	}
+
+	if info.Currency == "" {
+		return "", "", fmt.Errorf("currency not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return info.Currency, info.CurrencySymbol, nil
 }
 
 // FetchHistoricalData retrieves historical price data for the ticker from Yahoo Finance.
@@ -108,6 +161,60 @@ func (t *Ticker) FetchPriceValue() (PriceValue, error) {
 //   - period1: start timestamp (optional, can be empty string)
 //   - period2: end timestamp (optional, can be empty string)
 func (t *Ticker) FetchHistoricalData(rangeParam, interval, period1, period2 string) (map[string]PriceData, error) {
+	return t.FetchHistoricalWithQuery(Query{Range: rangeParam, Interval: interval, Start: period1, End: period2})
+}
+
+// FetchHistoricalWithQuery retrieves historical price data as FetchHistoricalData does, with the parameters
+// gathered in q. Empty fields get the defaults of Query.SetDefault, and Start and End are the Unix timestamps
+// sent as period1 and period2. The range is left out when either is set, as it would take precedence over them.
+func (t *Ticker) FetchHistoricalWithQuery(q Query) (map[string]PriceData, error) {
+	q.SetDefault()
+	params := historyParams(q.Range, q.Interval, q.Start, q.End)
+	if q.IncludePrePost {
+		params.Add("includePrePost", "true")
+	}
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transform and return the data
+	return transformHistoricalData(historyResponse, params.Get("interval")), nil
+}
+
+// FetchHistoricalDataBetween retrieves historical price data between start and end, converting both to the
+// Unix seconds Yahoo expects for period1 and period2. The interval defaults to 1d when empty.
+// An error matching ErrInvalidParameter is returned unless start is before end, or when end is past today (UTC).
+func (t *Ticker) FetchHistoricalDataBetween(start, end time.Time, interval string) (map[string]PriceData, error) {
+	if !start.Before(end) {
+		return nil, fmt.Errorf("start %s must be before end %s: %w", start.Format(time.RFC3339), end.Format(time.RFC3339), ErrInvalidParameter)
+	}
+	year, month, day := now().UTC().Date()
+	if tomorrow := time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC); end.After(tomorrow) {
+		return nil, fmt.Errorf("end %s is in the future: %w", end.Format(time.RFC3339), ErrInvalidParameter)
+	}
+	if interval == "" {
+		interval = "1d"
+	}
+
+	// The range parameter is left out, as it would take precedence over the periods
+	params := url.Values{}
+	params.Add("interval", interval)
+	params.Add("period1", strconv.FormatInt(start.Unix(), 10))
+	params.Add("period2", strconv.FormatInt(end.Unix(), 10))
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformHistoricalData(historyResponse, interval), nil
+}
+
+// historyParams builds the chart query parameters shared by the historical data methods,
+// defaulting to a year of daily bars. The range is only sent without period1 and period2.
+func historyParams(rangeParam, interval, period1, period2 string) url.Values {
 	// Set default values if not provided
 	if interval == "" {
 		interval = "1d"
@@ -116,10 +223,11 @@ func (t *Ticker) FetchHistoricalData(rangeParam, interval, period1, period2 stri
 		rangeParam = "1y"
 	}
 
-	// Build query parameters
+	// Build query parameters, leaving the range out alongside periods as it would take precedence over them
 	params := url.Values{}
-	params.Add("range", rangeParam)
-
+	if period1 == "" && period2 == "" {
+		params.Add("range", rangeParam)
+	}
 	params.Add("interval", interval)
 	if period1 != "" {
 		params.Add("period1", period1)
@@ -127,43 +235,20 @@ func (t *Ticker) FetchHistoricalData(rangeParam, interval, period1, period2 stri
 	if period2 != "" {
 		params.Add("period2", period2)
 	}
-
-	// Build the endpoint URL
-	endpoint := fmt.Sprintf("%s/v8/finance/chart/%s", BaseUrl, t.Symbol)
-
-	// Make the HTTP request
-	resp, err := t.Client.Get(endpoint, params)
-	if err != nil {
-		slog.Error("Failed to get historical data", "err", err)
-		return nil, err
-	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
-		}
-	}(resp.Body)
-
-	// Decode the JSON response
-	var historyResponse YahooHistoryResponse
-	if err := json.NewDecoder(resp.Body).Decode(&historyResponse); err != nil {
-		return nil, fmt.Errorf("failed to decode history data JSON response: %v", err)
-	}
-
-	// Check if we have data
-	if len(historyResponse.Chart.Result) == 0 {
-		return nil, fmt.Errorf("no data found for symbol: %s", t.Symbol)
-	}
-
-	// Transform and return the data
-	return transformHistoricalData(historyResponse, interval), nil
+	return params
 }
 
+// maxNewsRequests bounds the requests FetchNews makes to gather a page of articles
+const maxNewsRequests = 5
+
 // FetchNews retrieves recent news articles related to the ticker from Yahoo Finance.
 // Parameters:
 //   - count: number of news articles to fetch (optional, defaults to 10)
-//   - start: starting index for pagination (optional, defaults to 0)
+//   - start: number of articles to skip, for pagination (optional, defaults to 0)
 //
+// Articles are sorted newest first. The search endpoint has no offset, so the first start+count articles
+// are requested and the first start are dropped. Articles are de-duplicated by UUID, or link when they have
+// none, and more are requested while duplicates leave the page short.
 // Returns a slice of NewsItem structs containing news articles related to the ticker
 func (t *Ticker) FetchNews(count, start int) ([]NewsItem, error) {
 	// Set default values if not provided
@@ -174,54 +259,111 @@ func (t *Ticker) FetchNews(count, start int) ([]NewsItem, error) {
 		start = 0
 	}
 
+	wanted := start + count
+	newsCount := wanted
+	var news []NewsItem
+	seen := make(map[string]bool)
+	for i := 0; i < maxNewsRequests && len(news) < wanted; i++ {
+		page, err := t.fetchNewsPage(newsCount)
+		if err != nil {
+			return nil, err
+		}
+
+		added := 0
+		for _, item := range page {
+			key := newsKey(item)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			news = append(news, item)
+			added++
+		}
+
+		// Fewer articles than requested, or no new one, means Yahoo has no more to give
+		if len(page) < newsCount || added == 0 {
+			break
+		}
+		newsCount += wanted - len(news)
+	}
+
+	if len(news) <= start {
+		return []NewsItem{}, nil
+	}
+	sort.SliceStable(news, func(i, j int) bool { return news[i].ProviderPublishTime > news[j].ProviderPublishTime })
+	return news[start:min(len(news), wanted)], nil
+}
+
+// newsKey identifies an article for de-duplication: by UUID, else by link, else by title and publish time,
+// so that articles without a UUID or link aren't all taken for the same one
+func newsKey(item NewsItem) string {
+	switch {
+	case item.UUID != "":
+		return "uuid:" + item.UUID
+	case item.Link != "":
+		return "link:" + item.Link
+	default:
+		return fmt.Sprintf("title:%d:%s", item.ProviderPublishTime, item.Title)
+	}
+}
+
+// FetchNewsSince retrieves up to count of the ticker's latest news articles, keeping only those published
+// after since, e.g. the time of the previous poll. count defaults to 10 as for FetchNews.
+func (t *Ticker) FetchNewsSince(since time.Time, count int) ([]NewsItem, error) {
+	news, err := t.FetchNews(count, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	recent := make([]NewsItem, 0, len(news))
+	for _, item := range news {
+		if item.PublishedAt().After(since) {
+			recent = append(recent, item)
+		}
+	}
+	return recent, nil
+}
+
+// fetchNewsPage requests the ticker's first newsCount articles from the search endpoint
+func (t *Ticker) fetchNewsPage(newsCount int) ([]NewsItem, error) {
 	// Build query parameters
 	params := url.Values{}
-	params.Add("symbols", t.Symbol)
-	params.Add("count", fmt.Sprintf("%d", count))
-	params.Add("start", fmt.Sprintf("%d", start))
+	params.Add("q", t.Symbol)
+	params.Add("quotesCount", "0")
+	params.Add("newsCount", strconv.Itoa(newsCount))
 	params.Add("region", "US")
 	params.Add("lang", "en-US")
 
 	// Build the endpoint URL for Yahoo Finance news API
-	endpoint := fmt.Sprintf("%s/v1/finance/search", BaseUrl)
+	endpoint := fmt.Sprintf("%s/v1/finance/search", t.Client.baseURL())
 
 	// Make the HTTP request
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get news", "err", err)
+		t.Client.log().Error("Failed to get news", "err", err)
 		return nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
-	// Read response body for debugging if needed
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Try alternative news endpoint if the first one doesn't work
+	// The articles are usually at the top level, but some responses nest them under result
 	var newsResponse struct {
-		News []NewsItem `json:"news"`
+		News   []NewsItem `json:"news"`
+		Result struct {
+			News []NewsItem `json:"news"`
+		} `json:"result"`
 	}
-
-	if err := json.Unmarshal(bodyBytes, &newsResponse); err != nil {
-		// If that fails, try a different structure
-		var altResponse struct {
-			Result struct {
-				News []NewsItem `json:"news"`
-			} `json:"result"`
-		}
-		if err := json.Unmarshal(bodyBytes, &altResponse); err != nil {
-			return nil, fmt.Errorf("failed to decode news JSON response: %v", err)
-		}
-		return altResponse.Result.News, nil
+	if err := json.NewDecoder(resp.Body).Decode(&newsResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode news JSON response: %v", err)
 	}
 
+	if len(newsResponse.News) == 0 {
+		return newsResponse.Result.News, nil
+	}
 	return newsResponse.News, nil
 }
 
@@ -233,18 +375,18 @@ func (t *Ticker) FetchNewsAlternative() ([]NewsItem, error) {
 	params.Add("modules", "recommendationTrend,upgradeDowngradeHistory")
 
 	// Build the endpoint URL
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BaseUrl, t.Symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
 
 	// Make the HTTP request
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get alternative news", "err", err)
+		t.Client.log().Error("Failed to get alternative news", "err", err)
 		return nil, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
@@ -261,18 +403,18 @@ func (t *Ticker) FetchFinancialData() (FinancialData, error) {
 	params.Add("modules", "defaultKeyStatistics,financialData,summaryDetail,incomeStatementHistory,balanceSheetHistory,cashflowStatementHistory")
 
 	// Build the endpoint URL
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BaseUrl, t.Symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
 
 	// Make the HTTP request
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get financial data", "err", err)
+		t.Client.log().Error("Failed to get financial data", "err", err)
 		return FinancialData{}, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
@@ -284,7 +426,7 @@ func (t *Ticker) FetchFinancialData() (FinancialData, error) {
 
 	// Check if we have data
 	if len(financialResponse.QuoteSummary.Result) == 0 {
-		return FinancialData{}, fmt.Errorf("no financial data found for symbol: %s", t.Symbol)
+		return FinancialData{}, emptyResultError("financial data", t.Symbol, financialResponse.QuoteSummary.Error)
 	}
 
 	result := financialResponse.QuoteSummary.Result[0]
@@ -298,17 +440,17 @@ func (t *Ticker) FetchFinancialRatios() (FinancialRatios, error) {
 	params := url.Values{}
 	params.Add("modules", "defaultKeyStatistics,financialData,summaryDetail")
 
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BaseUrl, t.Symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
 
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get financial ratios", "err", err)
+		t.Client.log().Error("Failed to get financial ratios", "err", err)
 		return FinancialRatios{}, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
@@ -318,7 +460,7 @@ func (t *Ticker) FetchFinancialRatios() (FinancialRatios, error) {
 	}
 
 	if len(financialResponse.QuoteSummary.Result) == 0 {
-		return FinancialRatios{}, fmt.Errorf("no financial ratios found for symbol: %s", t.Symbol)
+		return FinancialRatios{}, emptyResultError("financial ratios", t.Symbol, financialResponse.QuoteSummary.Error)
 	}
 
 	result := financialResponse.QuoteSummary.Result[0]
@@ -330,17 +472,17 @@ func (t *Ticker) FetchKeyStatistics() (FinancialSummary, error) {
 	params := url.Values{}
 	params.Add("modules", "defaultKeyStatistics,summaryDetail")
 
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BaseUrl, t.Symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
 
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get key statistics", "err", err)
+		t.Client.log().Error("Failed to get key statistics", "err", err)
 		return FinancialSummary{}, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
@@ -350,7 +492,7 @@ func (t *Ticker) FetchKeyStatistics() (FinancialSummary, error) {
 	}
 
 	if len(financialResponse.QuoteSummary.Result) == 0 {
-		return FinancialSummary{}, fmt.Errorf("no key statistics found for symbol: %s", t.Symbol)
+		return FinancialSummary{}, emptyResultError("key statistics", t.Symbol, financialResponse.QuoteSummary.Error)
 	}
 
 	result := financialResponse.QuoteSummary.Result[0]
@@ -362,17 +504,17 @@ func (t *Ticker) FetchIncomeStatement() (IncomeStatement, error) {
 	params := url.Values{}
 	params.Add("modules", "incomeStatementHistory")
 
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BaseUrl, t.Symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
 
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get income statement", "err", err)
+		t.Client.log().Error("Failed to get income statement", "err", err)
 		return IncomeStatement{}, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
@@ -382,7 +524,7 @@ func (t *Ticker) FetchIncomeStatement() (IncomeStatement, error) {
 	}
 
 	if len(financialResponse.QuoteSummary.Result) == 0 {
-		return IncomeStatement{}, fmt.Errorf("no income statement found for symbol: %s", t.Symbol)
+		return IncomeStatement{}, emptyResultError("income statement", t.Symbol, financialResponse.QuoteSummary.Error)
 	}
 
 	result := financialResponse.QuoteSummary.Result[0]
@@ -394,17 +536,17 @@ func (t *Ticker) FetchBalanceSheet() (BalanceSheet, error) {
 	params := url.Values{}
 	params.Add("modules", "balanceSheetHistory")
 
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BaseUrl, t.Symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
 
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get balance sheet", "err", err)
+		t.Client.log().Error("Failed to get balance sheet", "err", err)
 		return BalanceSheet{}, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
@@ -414,7 +556,7 @@ func (t *Ticker) FetchBalanceSheet() (BalanceSheet, error) {
 	}
 
 	if len(financialResponse.QuoteSummary.Result) == 0 {
-		return BalanceSheet{}, fmt.Errorf("no balance sheet found for symbol: %s", t.Symbol)
+		return BalanceSheet{}, emptyResultError("balance sheet", t.Symbol, financialResponse.QuoteSummary.Error)
 	}
 
 	result := financialResponse.QuoteSummary.Result[0]
@@ -426,17 +568,17 @@ func (t *Ticker) FetchCashFlow() (CashFlow, error) {
 	params := url.Values{}
 	params.Add("modules", "cashflowStatementHistory")
 
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BaseUrl, t.Symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
 
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get cash flow", "err", err)
+		t.Client.log().Error("Failed to get cash flow", "err", err)
 		return CashFlow{}, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
@@ -446,13 +588,72 @@ func (t *Ticker) FetchCashFlow() (CashFlow, error) {
 	}
 
 	if len(financialResponse.QuoteSummary.Result) == 0 {
-		return CashFlow{}, fmt.Errorf("no cash flow found for symbol: %s", t.Symbol)
+		return CashFlow{}, emptyResultError("cash flow", t.Symbol, financialResponse.QuoteSummary.Error)
 	}
 
 	result := financialResponse.QuoteSummary.Result[0]
 	return t.extractCashFlow(result), nil
 }
 
+// FetchIncomeStatementHistory retrieves every annual income statement Yahoo reports, most recent first,
+// so that trends can be computed across years. ErrNoData is returned when there is none.
+func (t *Ticker) FetchIncomeStatementHistory() ([]IncomeStatement, error) {
+	result, err := t.fetchFinancialResult("incomeStatementHistory")
+	if err != nil {
+		return nil, err
+	}
+
+	statements := t.extractIncomeStatementHistory(result)
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no income statements found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+	return statements, nil
+}
+
+// FetchBalanceSheetHistory retrieves every annual balance sheet Yahoo reports, most recent first.
+// ErrNoData is returned when there is none.
+func (t *Ticker) FetchBalanceSheetHistory() ([]BalanceSheet, error) {
+	result, err := t.fetchFinancialResult("balanceSheetHistory")
+	if err != nil {
+		return nil, err
+	}
+
+	statements := t.extractBalanceSheetHistory(result)
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no balance sheets found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+	return statements, nil
+}
+
+// FetchCashFlowHistory retrieves every annual cash flow statement Yahoo reports, most recent first.
+// ErrNoData is returned when there is none.
+func (t *Ticker) FetchCashFlowHistory() ([]CashFlow, error) {
+	result, err := t.fetchFinancialResult("cashflowStatementHistory")
+	if err != nil {
+		return nil, err
+	}
+
+	statements := t.extractCashFlowHistory(result)
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("no cash flow statements found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+	return statements, nil
+}
+
+// fetchFinancialResult requests the given quoteSummary modules and decodes them as a YahooFinancialResult
+func (t *Ticker) fetchFinancialResult(modules string) (YahooFinancialResult, error) {
+	raw, err := t.fetchQuoteSummary(modules)
+	if err != nil {
+		return YahooFinancialResult{}, err
+	}
+
+	var result YahooFinancialResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return YahooFinancialResult{}, fmt.Errorf("failed to decode %s JSON response: %v", modules, err)
+	}
+	return result, nil
+}
+
 // DividendInfo represents dividend-related information for a stock
 type DividendInfo struct {
 	DividendRate             *PriceValue `json:"dividendRate"`             // Annual dividend per share
@@ -464,6 +665,39 @@ type DividendInfo struct {
 	FiveYearAvgDividendYield *PriceValue `json:"fiveYearAvgDividendYield"` // 5-year average dividend yield
 }
 
+// ExDividendDateTime returns the ex-dividend date, or the zero time.Time when it is unknown
+func (d DividendInfo) ExDividendDateTime() time.Time {
+	return d.ExDividendDate.AsTime()
+}
+
+// DividendDateTime returns the dividend payment date, or the zero time.Time when it is unknown
+func (d DividendInfo) DividendDateTime() time.Time {
+	return d.DividendDate.AsTime()
+}
+
+// Yield returns the current dividend yield as a fraction, or ErrNoData when it is unknown.
+// Together with Rate and IsPaying, it reads an already fetched DividendInfo, so that several figures
+// can be derived from a single FetchDividendInfo call.
+func (d DividendInfo) Yield() (float64, error) {
+	if d.DividendYield == nil {
+		return 0, fmt.Errorf("dividend yield not available: %w", ErrNoData)
+	}
+	return d.DividendYield.Raw, nil
+}
+
+// Rate returns the annual dividend per share, or ErrNoData when it is unknown
+func (d DividendInfo) Rate() (float64, error) {
+	if d.DividendRate == nil {
+		return 0, fmt.Errorf("dividend rate not available: %w", ErrNoData)
+	}
+	return d.DividendRate.Raw, nil
+}
+
+// IsPaying reports whether the stock currently pays dividends, i.e. has a positive dividend rate
+func (d DividendInfo) IsPaying() bool {
+	return d.DividendRate != nil && d.DividendRate.Raw > 0
+}
+
 // FetchDividendInfo retrieves comprehensive dividend information for the ticker
 // Returns dividend rate, yield, payment history, and related metrics
 func (t *Ticker) FetchDividendInfo() (DividendInfo, error) {
@@ -471,45 +705,25 @@ func (t *Ticker) FetchDividendInfo() (DividendInfo, error) {
 	params := url.Values{}
 	params.Add("modules", "summaryDetail,defaultKeyStatistics,cashflowStatementHistory,calendarEvents")
 
-	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", BaseUrl, t.Symbol)
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
 
-	resp, err := t.Client.Get(endpoint, params)
+	resp, err := t.get(endpoint, params)
 	if err != nil {
-		slog.Error("Failed to get dividend info", "err", err)
+		t.Client.log().Error("Failed to get dividend info", "err", err)
 		return DividendInfo{}, err
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
 		if err != nil {
-			slog.Error("Failed to close response body", "err", err)
+			t.Client.log().Error("Failed to close response body", "err", err)
 		}
 	}(resp.Body)
 
 	// Decode the JSON response
 	var financialResponse struct {
 		QuoteSummary struct {
-			Result []struct {
-				SummaryDetail *struct {
-					DividendRate             *PriceValue `json:"dividendRate"`
-					DividendYield            *PriceValue `json:"dividendYield"`
-					ExDividendDate           *PriceValue `json:"exDividendDate"`
-					DividendDate             *PriceValue `json:"dividendDate"`
-					PayoutRatio              *PriceValue `json:"payoutRatio"`
-					FiveYearAvgDividendYield *PriceValue `json:"fiveYearAvgDividendYield"`
-				} `json:"summaryDetail"`
-				DefaultKeyStatistics *struct {
-					DividendRate             *PriceValue `json:"dividendRate"`
-					DividendYield            *PriceValue `json:"dividendYield"`
-					PayoutRatio              *PriceValue `json:"payoutRatio"`
-					FiveYearAvgDividendYield *PriceValue `json:"fiveYearAvgDividendYield"`
-				} `json:"defaultKeyStatistics"`
-				CashflowStatementHistory *struct {
-					CashflowStatements []struct {
-						DividendsPaid *PriceValue `json:"dividendsPaid"`
-					} `json:"cashflowStatements"`
-				} `json:"cashflowStatementHistory"`
-			} `json:"result"`
-			Error interface{} `json:"error"`
+			Result []yahooDividendResult `json:"result"`
+			Error  interface{}           `json:"error"`
 		} `json:"quoteSummary"`
 	}
 
@@ -518,48 +732,49 @@ func (t *Ticker) FetchDividendInfo() (DividendInfo, error) {
 	}
 
 	if len(financialResponse.QuoteSummary.Result) == 0 {
-		return DividendInfo{}, fmt.Errorf("no dividend info found for symbol: %s", t.Symbol)
+		return DividendInfo{}, emptyResultError("dividend info", t.Symbol, financialResponse.QuoteSummary.Error)
 	}
 
 	result := financialResponse.QuoteSummary.Result[0]
 	return t.extractDividendInfo(result), nil
 }
 
-// FetchCurrentDividendYield retrieves just the current dividend yield for quick access
+// FetchCurrentDividendYield retrieves just the current dividend yield for quick access.
+// Each call fetches the dividend info; to read several figures, call FetchDividendInfo once
+// and use DividendInfo.Yield, Rate and IsPaying instead.
 func (t *Ticker) FetchCurrentDividendYield() (float64, error) {
 	dividendInfo, err := t.FetchDividendInfo()
 	if err != nil {
 		return 0, err
 	}
 
-	if dividendInfo.DividendYield == nil {
-		return 0, fmt.Errorf("dividend yield not available for symbol: %s", t.Symbol)
+	yield, err := dividendInfo.Yield()
+	if err != nil {
+		return 0, fmt.Errorf("symbol %s: %w", t.Symbol, err)
 	}
-
-	return dividendInfo.DividendYield.Raw, nil
+	return yield, nil
 }
 
-// FetchDividendRate retrieves the annual dividend rate per share
+// FetchDividendRate retrieves the annual dividend rate per share, see FetchCurrentDividendYield
 func (t *Ticker) FetchDividendRate() (float64, error) {
 	dividendInfo, err := t.FetchDividendInfo()
 	if err != nil {
 		return 0, err
 	}
 
-	if dividendInfo.DividendRate == nil {
-		return 0, fmt.Errorf("dividend rate not available for symbol: %s", t.Symbol)
+	rate, err := dividendInfo.Rate()
+	if err != nil {
+		return 0, fmt.Errorf("symbol %s: %w", t.Symbol, err)
 	}
-
-	return dividendInfo.DividendRate.Raw, nil
+	return rate, nil
 }
 
-// IsDividendPaying checks if the stock currently pays dividends
+// IsDividendPaying checks if the stock currently pays dividends, see FetchCurrentDividendYield
 func (t *Ticker) IsDividendPaying() (bool, error) {
 	dividendInfo, err := t.FetchDividendInfo()
 	if err != nil {
 		return false, err
 	}
 
-	// A stock is considered dividend-paying if it has a positive dividend rate
-	return dividendInfo.DividendRate != nil && dividendInfo.DividendRate.Raw > 0, nil
+	return dividendInfo.IsPaying(), nil
 }
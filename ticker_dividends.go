@@ -0,0 +1,109 @@
+package yfinance_api
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// YieldOnCost returns the current annual dividend rate divided by the given purchase price per share.
+// It returns ErrNoData when the symbol has no dividend rate.
+func (t *Ticker) YieldOnCost(purchasePrice float64) (float64, error) {
+	if purchasePrice <= 0 {
+		return 0, fmt.Errorf("purchase price must be positive, got %v", purchasePrice)
+	}
+
+	dividendInfo, err := t.FetchDividendInfo()
+	if err != nil {
+		return 0, err
+	}
+
+	rate, err := dividendInfo.Rate()
+	if err != nil {
+		return 0, fmt.Errorf("symbol %s: %w", t.Symbol, err)
+	}
+
+	return rate / purchasePrice, nil
+}
+
+// CurrentTrailingYield returns the dividends paid over the last 12 months divided by the current price.
+// Unlike the dividendYield reported by Yahoo, which can lag the market, both figures come from the same
+// chart request, so the yield stays consistent with the latest price. Non-payers yield 0.
+func (t *Ticker) CurrentTrailingYield() (float64, error) {
+	params := url.Values{}
+	params.Add("range", "1y")
+	params.Add("interval", "1d")
+	params.Add("events", "div")
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return 0, err
+	}
+
+	meta := historyResponse.Chart.Result[0].Meta
+	if meta.RegularMarketPrice <= 0 {
+		return 0, fmt.Errorf("current price not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	location := exchangeLocation(meta.ExchangeTimezoneName, meta.Gmtoffset)
+	since := now().AddDate(-1, 0, 0)
+	var paid float64
+	for _, dividend := range dividendEvents(historyResponse, location) {
+		if dividend.Date.After(since) {
+			paid += dividend.Amount
+		}
+	}
+
+	return paid / meta.RegularMarketPrice, nil
+}
+
+// FetchDividendHistory retrieves the dividends actually paid over the given range (e.g. "1y", "5y", "max"),
+// oldest first and dated on their ex-dividend date. Unlike the dividend rate of FetchDividendInfo, which is
+// a current snapshot, this is the payment history. Non-payers return an empty slice.
+func (t *Ticker) FetchDividendHistory(rangeParam string) ([]DividendEvent, error) {
+	params := url.Values{}
+	params.Add("range", rangeParam)
+	params.Add("interval", "1d")
+	params.Add("events", "div")
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := historyResponse.Chart.Result[0].Meta
+	return dividendEvents(historyResponse, exchangeLocation(meta.ExchangeTimezoneName, meta.Gmtoffset)), nil
+}
+
+// FetchSplitHistory retrieves the stock splits over the given range (e.g. "5y", "max"), oldest first.
+// Symbols that never split return an empty slice.
+func (t *Ticker) FetchSplitHistory(rangeParam string) ([]SplitEvent, error) {
+	params := url.Values{}
+	params.Add("range", rangeParam)
+	params.Add("interval", "1d")
+	params.Add("events", "splits")
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := historyResponse.Chart.Result[0].Meta
+	return splitEvents(historyResponse, exchangeLocation(meta.ExchangeTimezoneName, meta.Gmtoffset)), nil
+}
+
+// FetchCapitalGains retrieves the capital gains distributions of a fund over the given range
+// (e.g. "1y", "5y", "max"), oldest first. Instruments that never distributed gains return an empty slice.
+func (t *Ticker) FetchCapitalGains(rangeParam string) ([]CapitalGainEvent, error) {
+	params := url.Values{}
+	params.Add("range", rangeParam)
+	params.Add("interval", "1d")
+	params.Add("events", "capitalGains")
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := historyResponse.Chart.Result[0].Meta
+	return capitalGainEvents(historyResponse, exchangeLocation(meta.ExchangeTimezoneName, meta.Gmtoffset)), nil
+}
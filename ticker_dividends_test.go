@@ -0,0 +1,171 @@
+package yfinance_api
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// TestYieldOnCost tests the yield on cost against a purchase price
+func TestYieldOnCost(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "KO", `{"summaryDetail":{"dividendRate":{"raw":1.94,"fmt":"1.94"}}}`)
+
+	yield, err := ticker.YieldOnCost(38.8)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if math.Abs(yield-0.05) > 1e-9 {
+		t.Errorf("Expected yield on cost 0.05, got %v", yield)
+	}
+
+	for _, price := range []float64{0, -10} {
+		if _, err := ticker.YieldOnCost(price); err == nil {
+			t.Errorf("Expected an error for purchase price %v", price)
+		}
+	}
+}
+
+// TestYieldOnCostNoDividend tests that a missing dividend rate is reported as ErrNoData
+func TestYieldOnCostNoDividend(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AMZN", `{"summaryDetail":{}}`)
+
+	if _, err := ticker.YieldOnCost(100); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+// TestDividendInfoGetters tests deriving several figures from a single fetched DividendInfo
+func TestDividendInfoGetters(t *testing.T) {
+	requests := newChartServer(t, `{"quoteSummary":{"result":[{"summaryDetail":{"dividendRate":{"raw":0.96,"fmt":"0.96"},"dividendYield":{"raw":0.0052,"fmt":"0.52%"}}}],"error":null}}`)
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+
+	info, err := ticker.FetchDividendInfo()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if yield, err := info.Yield(); err != nil || yield != 0.0052 {
+		t.Errorf("Expected yield 0.0052, got %v (%v)", yield, err)
+	}
+	if rate, err := info.Rate(); err != nil || rate != 0.96 {
+		t.Errorf("Expected rate 0.96, got %v (%v)", rate, err)
+	}
+	if !info.IsPaying() {
+		t.Error("Expected the stock to pay dividends")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected a single request, got %d", got)
+	}
+
+	var empty DividendInfo
+	if _, err := empty.Yield(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData for a missing yield, got %v", err)
+	}
+	if _, err := empty.Rate(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData for a missing rate, got %v", err)
+	}
+	if empty.IsPaying() {
+		t.Error("Expected a missing rate not to count as paying")
+	}
+}
+
+// TestCurrentTrailingYield tests summing the last 12 months of dividends against the chart price
+func TestCurrentTrailingYield(t *testing.T) {
+	setNow(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	// Dividends on 2023-05-12 (older than 12 months), 2023-08-11, 2023-11-10, 2024-02-09 and 2024-05-10
+	newChartServer(t, `{"chart":{"result":[{"meta":{"regularMarketPrice":200,"exchangeTimezoneName":"America/New_York","gmtoffset":-14400},
+		"events":{"dividends":{
+			"1683898200":{"amount":0.24,"date":1683898200},"1691760600":{"amount":0.24,"date":1691760600},
+			"1699626600":{"amount":0.24,"date":1699626600},"1707489000":{"amount":0.24,"date":1707489000},
+			"1715347800":{"amount":0.25,"date":1715347800}}}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	yield, err := ticker.CurrentTrailingYield()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if expected := 0.97 / 200; math.Abs(yield-expected) > 1e-9 {
+		t.Errorf("Expected trailing yield %v, got %v", expected, yield)
+	}
+}
+
+// TestCurrentTrailingYieldNoPrice tests that a missing price returns ErrNoData
+func TestCurrentTrailingYieldNoPrice(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York"}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	if _, err := ticker.CurrentTrailingYield(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+// TestFetchCapitalGains tests parsing capital gains distributions from the chart events
+func TestFetchCapitalGains(t *testing.T) {
+	requests := newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-18000},
+		"events":{"capitalGains":{
+			"1702650600":{"amount":1.12,"date":1702650600},"1671114600":{"amount":0.87,"date":1671114600}}}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("VFIAX")
+	gains, err := ticker.FetchCapitalGains("5y")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(gains) != 2 {
+		t.Fatalf("Expected 2 distributions, got %d", len(gains))
+	}
+	if gains[0].Date.Format("2006-01-02") != "2022-12-15" || gains[0].Amount != 0.87 {
+		t.Errorf("Unexpected first distribution: %+v", gains[0])
+	}
+	if gains[1].Date.Format("2006-01-02") != "2023-12-15" || gains[1].Amount != 1.12 {
+		t.Errorf("Unexpected second distribution: %+v", gains[1])
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected a single request, got %d", requests.Load())
+	}
+}
+
+// TestFetchDividendHistory tests parsing the dividend events of the chart, oldest first
+func TestFetchDividendHistory(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-14400},
+		"events":{"dividends":{
+			"1715347800":{"amount":0.25,"date":1715347800},"1707489000":{"amount":0.24,"date":1707489000}}}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	dividends, err := ticker.FetchDividendHistory("1y")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(dividends) != 2 {
+		t.Fatalf("Expected 2 dividends, got %d", len(dividends))
+	}
+	if dividends[0].Date.Format("2006-01-02") != "2024-02-09" || dividends[0].Amount != 0.24 {
+		t.Errorf("Unexpected first dividend: %+v", dividends[0])
+	}
+}
+
+// TestFetchSplitHistory tests parsing the split events of the chart, including the ratio string
+func TestFetchSplitHistory(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-14400},
+		"events":{"splits":{
+			"1598880600":{"date":1598880600,"numerator":4.0,"denominator":1.0,"splitRatio":"4:1"},
+			"1402321800":{"date":1402321800,"numerator":7.0,"denominator":1.0,"splitRatio":"7:1"}}}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	splits, err := ticker.FetchSplitHistory("max")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(splits) != 2 {
+		t.Fatalf("Expected 2 splits, got %d", len(splits))
+	}
+	if splits[0].Numerator != 7 || splits[0].Denominator != 1 || splits[0].SplitRatio != "7:1" {
+		t.Errorf("Unexpected first split: %+v", splits[0])
+	}
+	if splits[1].Date.Format("2006-01-02") != "2020-08-31" {
+		t.Errorf("Unexpected second split date: %s", splits[1].Date)
+	}
+}
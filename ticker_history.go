@@ -0,0 +1,161 @@
+package yfinance_api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+// fetchChart requests the v8 chart endpoint for the ticker with the given query parameters.
+// The exchange timezone found in the response meta is cached for the symbol along the way.
+func (t *Ticker) fetchChart(params url.Values) (YahooHistoryResponse, error) {
+	// Build the endpoint URL
+	endpoint := fmt.Sprintf("%s/v8/finance/chart/%s", t.Client.baseURL(), t.Symbol)
+
+	// Make the HTTP request
+	resp, err := t.get(endpoint, params)
+	if err != nil {
+		t.Client.log().Error("Failed to get historical data", "err", err)
+		return YahooHistoryResponse{}, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.Client.log().Error("Failed to close response body", "err", err)
+		}
+	}(resp.Body)
+
+	// Decode the JSON response
+	var historyResponse YahooHistoryResponse
+	if err := json.NewDecoder(resp.Body).Decode(&historyResponse); err != nil {
+		return YahooHistoryResponse{}, fmt.Errorf("failed to decode history data JSON response: %v", err)
+	}
+
+	// Check if we have data, surfacing Yahoo's own explanation when it gives one
+	if len(historyResponse.Chart.Result) == 0 {
+		return YahooHistoryResponse{}, emptyResultError("chart", t.Symbol, historyResponse.Chart.Error)
+	}
+
+	meta := historyResponse.Chart.Result[0].Meta
+	if meta.ExchangeTimezoneName != "" {
+		t.Client.locations.Store(t.Symbol, exchangeLocation(meta.ExchangeTimezoneName, meta.Gmtoffset))
+	}
+
+	return historyResponse, nil
+}
+
+// ExchangeLocation returns the timezone of the exchange the ticker trades on.
+// It is resolved once per symbol from the chart meta (a lightweight one-day request) and cached on the client.
+// Zones unknown to the local tz database fall back to a fixed zone built from Yahoo's GMT offset.
+func (t *Ticker) ExchangeLocation() (*time.Location, error) {
+	if location, ok := t.Client.locations.Load(t.Symbol); ok {
+		return location.(*time.Location), nil
+	}
+
+	historyResponse, err := t.fetchChartMeta()
+	if err != nil {
+		return nil, err
+	}
+
+	meta := historyResponse.Chart.Result[0].Meta
+	location := exchangeLocation(meta.ExchangeTimezoneName, meta.Gmtoffset)
+	t.Client.locations.Store(t.Symbol, location)
+	return location, nil
+}
+
+// FirstTradeDate returns the earliest date Yahoo has trading history for, in the exchange timezone.
+// It reads firstTradeDate from the chart meta of a one-day request instead of downloading the full history,
+// which makes it cheap enough to bound a date-range picker per symbol.
+func (t *Ticker) FirstTradeDate() (time.Time, error) {
+	historyResponse, err := t.fetchChartMeta()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	meta := historyResponse.Chart.Result[0].Meta
+	if meta.FirstTradeDate == 0 {
+		return time.Time{}, fmt.Errorf("first trade date not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	location := exchangeLocation(meta.ExchangeTimezoneName, meta.Gmtoffset)
+	return time.Unix(meta.FirstTradeDate, 0).In(location), nil
+}
+
+// fetchChartMeta makes the lightest possible chart request, used when only the meta is needed
+func (t *Ticker) fetchChartMeta() (YahooHistoryResponse, error) {
+	params := url.Values{}
+	params.Add("range", "1d")
+	params.Add("interval", "1d")
+
+	return t.fetchChart(params)
+}
+
+// IsLatestBarToday reports whether Yahoo has already posted today's daily candle.
+// The date of the last candle with a close is compared to the current date, both in the exchange timezone,
+// so schedulers running after the close can confirm the new bar exists before processing it.
+func (t *Ticker) IsLatestBarToday() (bool, error) {
+	params := url.Values{}
+	params.Add("range", "5d")
+	params.Add("interval", "1d")
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return false, err
+	}
+
+	result := historyResponse.Chart.Result[0]
+	location := exchangeLocation(result.Meta.ExchangeTimezoneName, result.Meta.Gmtoffset)
+
+	latest, ok := latestCloseTime(historyResponse)
+	if !ok {
+		return false, fmt.Errorf("no daily candles found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return sameDay(latest.In(location), now().In(location)), nil
+}
+
+// tradingCalendar is the cached list of recent trading days for a symbol, valid for the exchange date it was built on
+type tradingCalendar struct {
+	builtOn string
+	days    []time.Time
+}
+
+// RecentTradingDays returns the last n trading days of the ticker's exchange, oldest first,
+// as midnight in the exchange timezone. The calendar is derived from daily history, since every date
+// with a candle is a trading day, so holidays are accounted for without a bundled holiday database.
+// The result is cached per symbol on the client until the exchange date changes.
+// An error matching ErrInvalidParameter is returned when n is not positive.
+func (t *Ticker) RecentTradingDays(n int) ([]time.Time, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("number of trading days must be positive, got %d: %w", n, ErrInvalidParameter)
+	}
+
+	if cached, ok := t.Client.tradingDays.Load(t.Symbol); ok {
+		calendar := cached.(tradingCalendar)
+		if location, ok := t.Client.locations.Load(t.Symbol); ok && len(calendar.days) >= n &&
+			calendar.builtOn == now().In(location.(*time.Location)).Format("2006-01-02") {
+			return append([]time.Time(nil), calendar.days[len(calendar.days)-n:]...), nil
+		}
+	}
+
+	params := url.Values{}
+	params.Add("range", tradingDaysRange(n))
+	params.Add("interval", "1d")
+
+	historyResponse, err := t.fetchChart(params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := historyResponse.Chart.Result[0]
+	location := exchangeLocation(result.Meta.ExchangeTimezoneName, result.Meta.Gmtoffset)
+	days := tradingDays(historyResponse, location)
+	if len(days) < n {
+		return nil, fmt.Errorf("only %d trading days available for symbol %s: %w", len(days), t.Symbol, ErrNoData)
+	}
+
+	t.Client.tradingDays.Store(t.Symbol, tradingCalendar{builtOn: now().In(location).Format("2006-01-02"), days: days})
+	return append([]time.Time(nil), days[len(days)-n:]...), nil
+}
@@ -0,0 +1,172 @@
+package yfinance_api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newChartServer serves the given chart JSON for every request and counts the requests
+func newChartServer(t *testing.T, body string) *atomic.Int32 {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	setBaseUrl(t, server.URL)
+	return &requests
+}
+
+// TestExchangeLocationFallback tests timezone resolution with and without a known zone name
+func TestExchangeLocationFallback(t *testing.T) {
+	location := exchangeLocation("America/New_York", -14400)
+	if location.String() != "America/New_York" {
+		t.Errorf("Expected America/New_York, got %s", location)
+	}
+
+	location = exchangeLocation("Mars/Olympus_Mons", 19800)
+	if _, offset := timeZoneAt(location); offset != 19800 {
+		t.Errorf("Expected fallback offset 19800, got %d", offset)
+	}
+
+	location = exchangeLocation("", -18000)
+	if _, offset := timeZoneAt(location); offset != -18000 {
+		t.Errorf("Expected fallback offset -18000, got %d", offset)
+	}
+}
+
+// TestExchangeLocation tests that the exchange timezone is fetched once and cached per symbol
+func TestExchangeLocation(t *testing.T) {
+	requests := newChartServer(t, `{"chart":{"result":[{"meta":{"symbol":"7203.T","exchangeTimezoneName":"Asia/Tokyo","gmtoffset":32400},"timestamp":[]}],"error":null}}`)
+
+	client := &YFinanceAPI{Client: newTestClient()}
+	ticker := client.InstantiateTicker("7203.T")
+
+	for i := 0; i < 2; i++ {
+		location, err := ticker.ExchangeLocation()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if location.String() != "Asia/Tokyo" {
+			t.Errorf("Expected Asia/Tokyo, got %s", location)
+		}
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("Expected a single chart request, got %d", requests.Load())
+	}
+}
+
+// timeZoneAt returns the zone name and offset of a location at a fixed instant
+func timeZoneAt(location *time.Location) (string, int) {
+	return time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC).In(location).Zone()
+}
+
+// TestFirstTradeDate tests reading the first trade date from the chart meta
+func TestFirstTradeDate(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"symbol":"AAPL","firstTradeDate":345479400,"exchangeTimezoneName":"America/New_York","gmtoffset":-14400}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	date, err := ticker.FirstTradeDate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := date.Format("2006-01-02 15:04"); got != "1980-12-12 09:30" {
+		t.Errorf("Expected 1980-12-12 09:30 exchange time, got %s", got)
+	}
+	if date.Location().String() != "America/New_York" {
+		t.Errorf("Expected America/New_York location, got %s", date.Location())
+	}
+}
+
+// TestFirstTradeDateMissing tests the error when the meta has no first trade date
+func TestFirstTradeDateMissing(t *testing.T) {
+	newChartServer(t, `{"chart":{"result":[{"meta":{"symbol":"NEW"}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("NEW")
+	if _, err := ticker.FirstTradeDate(); err == nil {
+		t.Error("Expected an error when firstTradeDate is missing")
+	}
+}
+
+// setNow freezes the package clock for the duration of the test
+func setNow(t *testing.T, frozen time.Time) {
+	previous := now
+	now = func() time.Time { return frozen }
+	t.Cleanup(func() { now = previous })
+}
+
+// TestIsLatestBarToday tests comparing the last daily candle to the exchange date
+func TestIsLatestBarToday(t *testing.T) {
+	// Daily candles stamped at the 09:30 New York open on 2024-03-14 and 2024-03-15; the last close is nil
+	newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-14400},
+		"timestamp":[1710423000,1710509400,1710768600],
+		"indicators":{"quote":[{"close":[171.1,172.6,null]}]}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	testCases := []struct {
+		name     string
+		now      time.Time
+		expected bool
+	}{
+		// 2024-03-16 01:00 UTC is still the evening of 2024-03-15 in New York
+		{"Same exchange day", time.Date(2024, 3, 16, 1, 0, 0, 0, time.UTC), true},
+		{"Next exchange day", time.Date(2024, 3, 18, 21, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			setNow(t, tc.now)
+
+			isToday, err := ticker.IsLatestBarToday()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if isToday != tc.expected {
+				t.Errorf("Expected %v, got %v", tc.expected, isToday)
+			}
+		})
+	}
+}
+
+// TestRecentTradingDays tests deriving trading days from daily candles and caching them per symbol
+func TestRecentTradingDays(t *testing.T) {
+	setNow(t, time.Date(2024, 3, 18, 20, 0, 0, 0, time.UTC))
+	// 2024-03-14, 2024-03-15 and 2024-03-18 at the New York open; the weekend has no candles
+	requests := newChartServer(t, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York","gmtoffset":-14400},
+		"timestamp":[1710423000,1710509400,1710768600],
+		"indicators":{"quote":[{"open":[1,2,3],"high":[1,2,3],"low":[1,2,3],"close":[1,2,3],"volume":[1,2,3]}]}}],"error":null}}`)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	days, err := ticker.RecentTradingDays(2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(days) != 2 || days[0].Format("2006-01-02") != "2024-03-15" || days[1].Format("2006-01-02") != "2024-03-18" {
+		t.Fatalf("Expected [2024-03-15 2024-03-18], got %v", days)
+	}
+	if days[0].Location().String() != "America/New_York" {
+		t.Errorf("Expected days in exchange time, got %s", days[0].Location())
+	}
+
+	if _, err := ticker.RecentTradingDays(3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected the cached calendar to be reused, got %d requests", requests.Load())
+	}
+
+	if _, err := ticker.RecentTradingDays(10); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData when history is too short, got %v", err)
+	}
+	if _, err := ticker.RecentTradingDays(0); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter for n = 0, got %v", err)
+	}
+}
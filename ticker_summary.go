@@ -0,0 +1,333 @@
+package yfinance_api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// fetchQuoteSummary requests the given comma-separated quoteSummary modules for the ticker
+// and returns the raw first result, leaving the decoding of the modules to the caller.
+func (t *Ticker) fetchQuoteSummary(modules string) (json.RawMessage, error) {
+	params := url.Values{}
+	params.Add("modules", modules)
+
+	endpoint := fmt.Sprintf("%s/v10/finance/quoteSummary/%s", t.Client.baseURL(), t.Symbol)
+
+	resp, err := t.get(endpoint, params)
+	if err != nil {
+		t.Client.log().Error("Failed to get quote summary", "modules", modules, "err", err)
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.Client.log().Error("Failed to close response body", "err", err)
+		}
+	}(resp.Body)
+
+	var summaryResponse struct {
+		QuoteSummary struct {
+			Result []json.RawMessage `json:"result"`
+			Error  interface{}       `json:"error"`
+		} `json:"quoteSummary"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&summaryResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode quote summary JSON response: %v", err)
+	}
+
+	if len(summaryResponse.QuoteSummary.Result) == 0 {
+		return nil, emptyResultError("quote summary", t.Symbol, summaryResponse.QuoteSummary.Error)
+	}
+
+	return summaryResponse.QuoteSummary.Result[0], nil
+}
+
+// FetchModules requests any quoteSummary modules, such as "secFilings" or "netSharePurchaseActivity",
+// and returns the raw result object keyed by module name for the caller to decode. It is an escape hatch
+// for the modules without a dedicated method. An error matching ErrInvalidParameter is returned
+// when no module is given.
+func (t *Ticker) FetchModules(modules []string) (json.RawMessage, error) {
+	if len(modules) == 0 {
+		return nil, fmt.Errorf("no quoteSummary module requested: %w", ErrInvalidParameter)
+	}
+	return t.fetchQuoteSummary(strings.Join(modules, ","))
+}
+
+// FetchESGInvolvement retrieves the business involvement flags from the esgScores module,
+// such as alcohol, gambling, tobacco or controversial weapons, for ethical screening.
+// Small caps often have no ESG coverage, in which case ErrNoData is returned.
+func (t *Ticker) FetchESGInvolvement() (InvolvementAreas, error) {
+	result, err := t.fetchQuoteSummary("esgScores")
+	if err != nil {
+		return InvolvementAreas{}, err
+	}
+
+	var summary struct {
+		EsgScores *InvolvementAreas `json:"esgScores"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return InvolvementAreas{}, fmt.Errorf("failed to decode ESG involvement JSON response: %v", err)
+	}
+
+	if summary.EsgScores == nil {
+		return InvolvementAreas{}, fmt.Errorf("no ESG data found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return *summary.EsgScores, nil
+}
+
+// FetchESGScores retrieves the total, environment, social and governance risk scores from the esgScores module,
+// along with the peer comparison and the highest controversy level.
+// Small caps often have no ESG coverage, in which case ErrNoData is returned.
+func (t *Ticker) FetchESGScores() (ESGScores, error) {
+	result, err := t.fetchQuoteSummary("esgScores")
+	if err != nil {
+		return ESGScores{}, err
+	}
+
+	var summary struct {
+		EsgScores *ESGScores `json:"esgScores"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return ESGScores{}, fmt.Errorf("failed to decode ESG scores JSON response: %v", err)
+	}
+
+	if summary.EsgScores == nil || summary.EsgScores.TotalEsg == nil {
+		return ESGScores{}, fmt.Errorf("no ESG data found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return *summary.EsgScores, nil
+}
+
+// FetchCalendar retrieves the upcoming earnings date and estimates along with the dividend dates
+// from the calendarEvents module. Absent dates are returned as zero times.
+func (t *Ticker) FetchCalendar() (Calendar, error) {
+	result, err := t.fetchQuoteSummary("calendarEvents")
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	var summary struct {
+		CalendarEvents *yahooCalendarEvents `json:"calendarEvents"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return Calendar{}, fmt.Errorf("failed to decode calendar JSON response: %v", err)
+	}
+
+	if summary.CalendarEvents == nil {
+		return Calendar{}, fmt.Errorf("no calendar found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return extractCalendar(*summary.CalendarEvents), nil
+}
+
+// FetchEarnings retrieves the reported versus estimated EPS of the last quarters, with the surprise,
+// along with quarterly revenue and earnings and the next earnings date, from the earnings and
+// earningsHistory modules. ErrNoData is returned when Yahoo has neither module for the ticker.
+func (t *Ticker) FetchEarnings() (Earnings, error) {
+	result, err := t.fetchQuoteSummary("earnings,earningsHistory")
+	if err != nil {
+		return Earnings{}, err
+	}
+
+	var modules yahooEarnings
+	if err := json.Unmarshal(result, &modules); err != nil {
+		return Earnings{}, fmt.Errorf("failed to decode earnings JSON response: %v", err)
+	}
+
+	if modules.Earnings == nil && modules.EarningsHistory == nil {
+		return Earnings{}, fmt.Errorf("no earnings found for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return extractEarnings(modules), nil
+}
+
+// FetchRecommendationTrend retrieves the number of strong buy, buy, hold, sell and strong sell ratings
+// for the current month and the previous ones, most recent first, from the recommendationTrend module.
+// ErrNoData is returned for tickers without analyst coverage.
+func (t *Ticker) FetchRecommendationTrend() ([]RecommendationPeriod, error) {
+	result, err := t.fetchQuoteSummary("recommendationTrend")
+	if err != nil {
+		return nil, err
+	}
+
+	var summary struct {
+		RecommendationTrend *struct {
+			Trend []RecommendationPeriod `json:"trend"`
+		} `json:"recommendationTrend"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode recommendation trend JSON response: %v", err)
+	}
+
+	if summary.RecommendationTrend == nil || len(summary.RecommendationTrend.Trend) == 0 {
+		return nil, fmt.Errorf("no recommendation trend for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return summary.RecommendationTrend.Trend, nil
+}
+
+// NextEarningsDate returns the soonest earnings date that is not yet past, from the calendarEvents module.
+// ErrNoData is returned when Yahoo has no upcoming earnings date for the ticker.
+func (t *Ticker) NextEarningsDate() (time.Time, error) {
+	calendar, err := t.FetchCalendar()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if calendar.NextEarningsDate.IsZero() {
+		return time.Time{}, fmt.Errorf("no upcoming earnings date for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return calendar.NextEarningsDate, nil
+}
+
+// FetchCompanyProfile retrieves the sector, industry, location, website, business summary, headcount
+// and officers of the company from the assetProfile module.
+// Funds and indices have no company behind them: Yahoo either omits the module or only fills in a
+// description, and ErrNoData is returned in both cases.
+func (t *Ticker) FetchCompanyProfile() (CompanyProfile, error) {
+	raw, err := t.fetchQuoteSummary("assetProfile")
+	if err != nil {
+		return CompanyProfile{}, err
+	}
+
+	var result YahooFinancialResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return CompanyProfile{}, fmt.Errorf("failed to decode asset profile JSON response: %v", err)
+	}
+
+	profile := result.AssetProfile
+	if profile == nil || (profile.Sector == "" && profile.Industry == "" && len(profile.CompanyOfficers) == 0) {
+		return CompanyProfile{}, fmt.Errorf("no company profile for symbol %s, which may be a fund or an index: %w", t.Symbol, ErrNoData)
+	}
+
+	return t.extractCompanyProfile(result), nil
+}
+
+// TargetUpside returns the upside (or downside when negative) from the current price to the mean analyst
+// target as a fraction, e.g. 0.12 for 12%, computed as (target - current) / current.
+// Both prices come from a single financialData request; ErrNoData is returned when either is missing.
+func (t *Ticker) TargetUpside() (float64, error) {
+	result, err := t.fetchQuoteSummary("financialData")
+	if err != nil {
+		return 0, err
+	}
+
+	var summary struct {
+		FinancialData *struct {
+			CurrentPrice    *PriceValue `json:"currentPrice"`
+			TargetMeanPrice *PriceValue `json:"targetMeanPrice"`
+		} `json:"financialData"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return 0, fmt.Errorf("failed to decode target price JSON response: %v", err)
+	}
+
+	data := summary.FinancialData
+	if data == nil || data.TargetMeanPrice == nil || data.TargetMeanPrice.Raw == 0 {
+		return 0, fmt.Errorf("no analyst target price for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+	if data.CurrentPrice == nil || data.CurrentPrice.Raw == 0 {
+		return 0, fmt.Errorf("no current price for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return (data.TargetMeanPrice.Raw - data.CurrentPrice.Raw) / data.CurrentPrice.Raw, nil
+}
+
+// PESpread returns the trailing and forward P/E along with the spread between them as a fraction,
+// computed as trailing/forward - 1. A positive spread means earnings are expected to grow.
+// P/E ratios are meaningless for loss-making companies, so when either is zero or negative both are
+// still returned but the spread is NaN. ErrNoData is returned when either ratio is missing.
+func (t *Ticker) PESpread() (trailing, forward, spreadPct float64, err error) {
+	summary, err := t.FetchKeyStatistics()
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	if summary.TrailingPE == nil || summary.ForwardPE == nil {
+		return 0, 0, 0, fmt.Errorf("trailing and forward P/E not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	trailing, forward = summary.TrailingPE.Raw, summary.ForwardPE.Raw
+	if trailing <= 0 || forward <= 0 {
+		return trailing, forward, math.NaN(), nil
+	}
+
+	return trailing, forward, trailing/forward - 1, nil
+}
+
+// FetchInsiderOwnershipTrend retrieves the percentage of shares held by insiders as a dated series, oldest first.
+// Yahoo only publishes the current figure (majorHoldersBreakdown, falling back to defaultKeyStatistics),
+// so the series currently holds a single point dated today; callers can build a history by sampling it over time.
+func (t *Ticker) FetchInsiderOwnershipTrend() ([]OwnershipPoint, error) {
+	result, err := t.fetchQuoteSummary("majorHoldersBreakdown,defaultKeyStatistics")
+	if err != nil {
+		return nil, err
+	}
+
+	var summary struct {
+		MajorHoldersBreakdown *struct {
+			InsidersPercentHeld *PriceValue `json:"insidersPercentHeld"`
+		} `json:"majorHoldersBreakdown"`
+		DefaultKeyStatistics *struct {
+			HeldPercentInsiders *PriceValue `json:"heldPercentInsiders"`
+		} `json:"defaultKeyStatistics"`
+	}
+	if err := json.Unmarshal(result, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode insider ownership JSON response: %v", err)
+	}
+
+	var percent *PriceValue
+	if summary.MajorHoldersBreakdown != nil {
+		percent = summary.MajorHoldersBreakdown.InsidersPercentHeld
+	}
+	if percent == nil && summary.DefaultKeyStatistics != nil {
+		percent = summary.DefaultKeyStatistics.HeldPercentInsiders
+	}
+	if percent == nil {
+		return nil, fmt.Errorf("insider ownership not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	year, month, day := now().UTC().Date()
+	return []OwnershipPoint{{Date: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Percent: percent.Raw}}, nil
+}
+
+// FreeCashFlowYield returns the latest annual free cash flow divided by the market capitalization.
+// Both come from a single quoteSummary request. When Yahoo doesn't report free cash flow directly it is
+// derived as operating cash flow minus capital expenditures, whose sign varies between statements.
+// A negative yield means the company burned cash. ErrNoData is returned when an input is missing.
+func (t *Ticker) FreeCashFlowYield() (float64, error) {
+	raw, err := t.fetchQuoteSummary("defaultKeyStatistics,summaryDetail,cashflowStatementHistory")
+	if err != nil {
+		return 0, err
+	}
+
+	var result YahooFinancialResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return 0, fmt.Errorf("failed to decode free cash flow JSON response: %v", err)
+	}
+
+	summary := t.extractFinancialSummary(result)
+	if summary.MarketCap == nil || summary.MarketCap.Raw <= 0 {
+		return 0, fmt.Errorf("market cap not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	cashflow := t.extractCashFlow(result)
+	var freeCashFlow float64
+	switch {
+	case cashflow.FreeCashFlow != nil:
+		freeCashFlow = cashflow.FreeCashFlow.Raw
+	case cashflow.OperatingCashFlow != nil && cashflow.CapitalExpenditures != nil:
+		freeCashFlow = cashflow.OperatingCashFlow.Raw - math.Abs(cashflow.CapitalExpenditures.Raw)
+	default:
+		return 0, fmt.Errorf("free cash flow not available for symbol %s: %w", t.Symbol, ErrNoData)
+	}
+
+	return freeCashFlow / summary.MarketCap.Raw, nil
+}
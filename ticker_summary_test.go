@@ -0,0 +1,462 @@
+package yfinance_api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newQuoteSummaryTicker serves the given quoteSummary result for every request
+// and returns a ticker bound to the test server
+func newQuoteSummaryTicker(t *testing.T, symbol, result string) *Ticker {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if result == "" {
+			fmt.Fprint(w, `{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"No fundamentals data found"}}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"quoteSummary":{"result":[%s],"error":null}}`, result)
+	}))
+	t.Cleanup(server.Close)
+	setBaseUrl(t, server.URL)
+
+	return (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker(symbol)
+}
+
+// TestFetchModules tests requesting arbitrary modules and returning the raw result
+func TestFetchModules(t *testing.T) {
+	var modules string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		modules = r.URL.Query().Get("modules")
+		fmt.Fprint(w, `{"quoteSummary":{"result":[{"secFilings":{"filings":[{"type":"10-Q"}]},"indexTrend":{"symbol":"SP5"}}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	raw, err := ticker.FetchModules([]string{"secFilings", "indexTrend"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if modules != "secFilings,indexTrend" {
+		t.Errorf("Expected both modules to be requested, got %q", modules)
+	}
+	var result struct {
+		SecFilings struct {
+			Filings []struct {
+				Type string `json:"type"`
+			} `json:"filings"`
+		} `json:"secFilings"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		t.Fatalf("Unexpected error decoding %s: %v", raw, err)
+	}
+	if len(result.SecFilings.Filings) != 1 || result.SecFilings.Filings[0].Type != "10-Q" {
+		t.Errorf("Unexpected raw result %s", raw)
+	}
+
+	if _, err := ticker.FetchModules(nil); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter without modules, got %v", err)
+	}
+}
+
+// TestFetchESGInvolvement tests parsing the involvement flags of the esgScores module
+func TestFetchESGInvolvement(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "MO", `{"esgScores":{"totalEsg":{"raw":26.3},"tobacco":true,"alcoholic":false,"gambling":false,"controversialWeapons":false}}`)
+
+	involvement, err := ticker.FetchESGInvolvement()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !involvement.Tobacco {
+		t.Error("Expected tobacco involvement")
+	}
+	if involvement.Alcoholic || involvement.Gambling || involvement.ControversialWeapons {
+		t.Errorf("Unexpected involvement flags: %+v", involvement)
+	}
+}
+
+// TestFetchESGInvolvementMissing tests the error for symbols without ESG coverage
+func TestFetchESGInvolvementMissing(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "TINY", "")
+
+	if _, err := ticker.FetchESGInvolvement(); err == nil {
+		t.Error("Expected an error for a symbol without ESG data")
+	}
+}
+
+// TestFetchESGScores tests parsing the risk scores of the esgScores module
+func TestFetchESGScores(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"esgScores":{"maxAge":86400,"totalEsg":{"raw":17.22,"fmt":"17.2"},
+		"environmentScore":{"raw":0.52},"socialScore":{"raw":7.43},"governanceScore":{"raw":9.27},
+		"ratingYear":2024,"ratingMonth":5,"highestControversy":3,"percentile":{"raw":12.5},
+		"esgPerformance":"OUT_PERF","peerGroup":"Technology Hardware","tobacco":false}}`)
+
+	scores, err := ticker.FetchESGScores()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if scores.TotalEsg.Raw != 17.22 || scores.EnvironmentScore.Raw != 0.52 || scores.SocialScore.Raw != 7.43 || scores.GovernanceScore.Raw != 9.27 {
+		t.Errorf("Unexpected scores: %+v", scores)
+	}
+	if scores.HighestControversy == nil || scores.HighestControversy.Raw != 3 || scores.Percentile.Raw != 12.5 {
+		t.Errorf("Unexpected controversy or percentile: %+v", scores)
+	}
+	if scores.EsgPerformance != "OUT_PERF" || scores.RatingYear != 2024 || scores.RatingMonth != 5 {
+		t.Errorf("Unexpected rating: %+v", scores)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "TINY", `{"esgScores":{"maxAge":86400}}`)
+	if _, err := ticker.FetchESGScores(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without scores, got %v", err)
+	}
+}
+
+// TestFetchCalendar tests parsing the calendarEvents module
+func TestFetchCalendar(t *testing.T) {
+	setNow(t, time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC))
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"calendarEvents":{"earnings":{
+		"earningsDate":[{"raw":1714680000,"fmt":"2024-05-02"},{"raw":1713470400,"fmt":"2024-04-18"}],
+		"earningsAverage":{"raw":1.5,"fmt":"1.50"},"earningsLow":{"raw":1.43,"fmt":"1.43"},"earningsHigh":{"raw":1.62,"fmt":"1.62"},
+		"revenueAverage":{"raw":90350000000,"fmt":"90.35B"}},
+		"exDividendDate":{"raw":1715299200,"fmt":"2024-05-10"}}}`)
+
+	calendar, err := ticker.FetchCalendar()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !calendar.NextEarningsDate.Equal(time.Unix(1714680000, 0)) {
+		t.Errorf("Expected next earnings date 2024-05-02, got %s", calendar.NextEarningsDate)
+	}
+	if calendar.EarningsAverage == nil || *calendar.EarningsAverage != 1.5 {
+		t.Error("Expected earnings average 1.5")
+	}
+	if calendar.EarningsLow == nil || calendar.EarningsHigh == nil {
+		t.Error("Expected earnings low and high estimates")
+	}
+	if len(calendar.EarningsDates) != 2 || !calendar.EarningsDates[0].Equal(time.Unix(1713470400, 0)) {
+		t.Errorf("Expected both earnings dates oldest first, got %v", calendar.EarningsDates)
+	}
+	if calendar.RevenueAverage == nil || *calendar.RevenueAverage != 90350000000 {
+		t.Error("Expected revenue average 90.35B")
+	}
+	if !calendar.ExDividendDate.Equal(time.Unix(1715299200, 0)) {
+		t.Errorf("Unexpected ex-dividend date %s", calendar.ExDividendDate)
+	}
+	if !calendar.DividendDate.IsZero() {
+		t.Errorf("Expected zero dividend date, got %s", calendar.DividendDate)
+	}
+}
+
+// TestNextEarningsDate tests the soonest future earnings date and the error when none is ahead
+func TestNextEarningsDate(t *testing.T) {
+	setNow(t, time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC))
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"calendarEvents":{"earnings":{
+		"earningsDate":[{"raw":1717200000},{"raw":1714680000}]}}}`)
+
+	date, err := ticker.NextEarningsDate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !date.Equal(time.Unix(1714680000, 0)) {
+		t.Errorf("Expected 2024-05-02, got %s", date)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "OLD", `{"calendarEvents":{"earnings":{"earningsDate":[{"raw":1713470400}]}}}`)
+	if _, err := ticker.NextEarningsDate(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without an upcoming date, got %v", err)
+	}
+}
+
+// TestTargetUpside tests the upside and downside to the mean analyst target
+func TestTargetUpside(t *testing.T) {
+	testCases := []struct {
+		name     string
+		result   string
+		expected float64
+	}{
+		{name: "Upside", result: `{"financialData":{"currentPrice":{"raw":100},"targetMeanPrice":{"raw":125}}}`, expected: 0.25},
+		{name: "Downside", result: `{"financialData":{"currentPrice":{"raw":200},"targetMeanPrice":{"raw":150}}}`, expected: -0.25},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ticker := newQuoteSummaryTicker(t, "AAPL", tc.result)
+
+			upside, err := ticker.TargetUpside()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if math.Abs(upside-tc.expected) > 1e-9 {
+				t.Errorf("Expected upside %v, got %v", tc.expected, upside)
+			}
+		})
+	}
+}
+
+// TestTargetUpsideMissing tests that a symbol without analyst coverage returns ErrNoData
+func TestTargetUpsideMissing(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "TINY", `{"financialData":{"currentPrice":{"raw":3.2}}}`)
+
+	if _, err := ticker.TargetUpside(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+// TestPESpread tests the spread between trailing and forward P/E
+func TestPESpread(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "MSFT", `{"defaultKeyStatistics":{"forwardPE":{"raw":30}},"summaryDetail":{"trailingPE":{"raw":36}}}`)
+
+	trailing, forward, spread, err := ticker.PESpread()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if trailing != 36 || forward != 30 {
+		t.Errorf("Expected P/E 36 and 30, got %v and %v", trailing, forward)
+	}
+	if math.Abs(spread-0.2) > 1e-9 {
+		t.Errorf("Expected spread 0.2, got %v", spread)
+	}
+}
+
+// TestPESpreadNegative tests that a negative P/E yields a NaN spread rather than a misleading number
+func TestPESpreadNegative(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "RIVN", `{"defaultKeyStatistics":{"forwardPE":{"raw":-8.5},"trailingPE":{"raw":-4.2}}}`)
+
+	trailing, forward, spread, err := ticker.PESpread()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if trailing != -4.2 || forward != -8.5 {
+		t.Errorf("Expected the raw P/E values, got %v and %v", trailing, forward)
+	}
+	if !math.IsNaN(spread) {
+		t.Errorf("Expected NaN spread, got %v", spread)
+	}
+}
+
+// TestPESpreadMissing tests that a missing forward P/E returns ErrNoData
+func TestPESpreadMissing(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "TINY", `{"summaryDetail":{"trailingPE":{"raw":12}}}`)
+
+	if _, _, _, err := ticker.PESpread(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+// TestFetchInsiderOwnershipTrend tests the single-point series built from the current insider ownership
+func TestFetchInsiderOwnershipTrend(t *testing.T) {
+	setNow(t, time.Date(2024, 6, 3, 15, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name   string
+		result string
+	}{
+		{name: "Major holders", result: `{"majorHoldersBreakdown":{"insidersPercentHeld":{"raw":0.0171,"fmt":"1.71%"}},"defaultKeyStatistics":{"heldPercentInsiders":{"raw":0.02}}}`},
+		{name: "Key statistics fallback", result: `{"defaultKeyStatistics":{"heldPercentInsiders":{"raw":0.0171,"fmt":"1.71%"}}}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ticker := newQuoteSummaryTicker(t, "AAPL", tc.result)
+
+			series, err := ticker.FetchInsiderOwnershipTrend()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(series) != 1 {
+				t.Fatalf("Expected a single point, got %d", len(series))
+			}
+			if series[0].Percent != 0.0171 {
+				t.Errorf("Expected 0.0171, got %v", series[0].Percent)
+			}
+			if got := series[0].Date.Format("2006-01-02"); got != "2024-06-03" {
+				t.Errorf("Expected the point dated 2024-06-03, got %s", got)
+			}
+		})
+	}
+
+	ticker := newQuoteSummaryTicker(t, "TINY", `{"majorHoldersBreakdown":{}}`)
+	if _, err := ticker.FetchInsiderOwnershipTrend(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+// TestFreeCashFlowYield tests the yield with reported and derived free cash flow
+func TestFreeCashFlowYield(t *testing.T) {
+	testCases := []struct {
+		name     string
+		result   string
+		expected float64
+	}{
+		{
+			name: "Reported",
+			result: `{"summaryDetail":{"marketCap":{"raw":1000}},
+				"cashflowStatementHistory":{"cashflowStatements":[{"freeCashFlow":{"raw":50}},{"freeCashFlow":{"raw":10}}]}}`,
+			expected: 0.05,
+		},
+		{
+			name: "Derived with negative capex",
+			result: `{"summaryDetail":{"marketCap":{"raw":1000}},
+				"cashflowStatementHistory":{"cashflowStatements":[{"totalCashFromOperatingActivities":{"raw":80},"capitalExpenditures":{"raw":-30}}]}}`,
+			expected: 0.05,
+		},
+		{
+			name: "Derived with positive capex",
+			result: `{"summaryDetail":{"marketCap":{"raw":1000}},
+				"cashflowStatementHistory":{"cashflowStatements":[{"totalCashFromOperatingActivities":{"raw":20},"capitalExpenditures":{"raw":30}}]}}`,
+			expected: -0.01,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ticker := newQuoteSummaryTicker(t, "AAPL", tc.result)
+
+			yield, err := ticker.FreeCashFlowYield()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if math.Abs(yield-tc.expected) > 1e-9 {
+				t.Errorf("Expected yield %v, got %v", tc.expected, yield)
+			}
+		})
+	}
+
+	ticker := newQuoteSummaryTicker(t, "TINY", `{"summaryDetail":{"marketCap":{"raw":1000}}}`)
+	if _, err := ticker.FreeCashFlowYield(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
+// TestFetchCompanyProfile tests extracting the assetProfile module
+func TestFetchCompanyProfile(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"assetProfile":{"city":"Cupertino","country":"United States",
+		"website":"https://www.apple.com","industry":"Consumer Electronics","sector":"Technology",
+		"longBusinessSummary":"Apple Inc. designs, manufactures, and markets smartphones.","fullTimeEmployees":161000,
+		"companyOfficers":[
+			{"name":"Mr. Timothy D. Cook","title":"CEO & Director","age":62,"totalPay":{"raw":16239562,"fmt":"16.24M"}},
+			{"name":"Ms. Kate Adams","title":"Senior VP, General Counsel & Secretary"}]}}`)
+
+	profile, err := ticker.FetchCompanyProfile()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if profile.Sector != "Technology" || profile.Industry != "Consumer Electronics" || profile.City != "Cupertino" {
+		t.Errorf("Unexpected profile: %+v", profile)
+	}
+	if profile.FullTimeEmployees == nil || *profile.FullTimeEmployees != 161000 {
+		t.Error("Expected 161000 full-time employees")
+	}
+	if len(profile.Officers) != 2 {
+		t.Fatalf("Expected 2 officers, got %d", len(profile.Officers))
+	}
+	if pay := profile.Officers[0].TotalPay; pay == nil || *pay != 16239562 {
+		t.Errorf("Unexpected pay for %s: %v", profile.Officers[0].Name, pay)
+	}
+	if age := profile.Officers[0].Age; age == nil || *age != 62 {
+		t.Errorf("Unexpected age for %s: %v", profile.Officers[0].Name, age)
+	}
+	if profile.Officers[1].TotalPay != nil || profile.Officers[1].Age != nil {
+		t.Error("Expected nil pay and age when undisclosed")
+	}
+}
+
+// TestFetchCompanyProfileFund tests the error for funds and indices, which have no company profile
+func TestFetchCompanyProfileFund(t *testing.T) {
+	testCases := []struct {
+		name   string
+		result string
+	}{
+		{name: "Description only", result: `{"assetProfile":{"longBusinessSummary":"The fund tracks the S&P 500.","maxAge":86400}}`},
+		{name: "Module missing", result: `{}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ticker := newQuoteSummaryTicker(t, "SPY", tc.result)
+			if _, err := ticker.FetchCompanyProfile(); !errors.Is(err, ErrNoData) {
+				t.Errorf("Expected ErrNoData, got %v", err)
+			}
+		})
+	}
+}
+
+// TestFetchEarnings tests parsing the earnings history, quarterly financials and next earnings date
+func TestFetchEarnings(t *testing.T) {
+	setNow(t, time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC))
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{
+		"earnings":{
+			"earningsChart":{"currentQuarterEstimate":{"raw":1.5,"fmt":"1.50"},"earningsDate":[{"raw":1714680000,"fmt":"2024-05-02"}]},
+			"financialsChart":{"quarterly":[{"date":"4Q2023","revenue":{"raw":119575000000},"earnings":{"raw":33916000000}}]}},
+		"earningsHistory":{"history":[
+			{"quarter":{"raw":1703980800,"fmt":"2023-12-31"},"period":"-1q","epsActual":{"raw":2.18},"epsEstimate":{"raw":2.1},
+				"epsDifference":{"raw":0.08},"surprisePercent":{"raw":0.038}},
+			{"quarter":{"raw":1696032000,"fmt":"2023-09-30"},"period":"-2q","epsActual":{"raw":1.46},"epsEstimate":{"raw":1.39}}]}}`)
+
+	earnings, err := ticker.FetchEarnings()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(earnings.History) != 2 || earnings.History[0].Period != "-2q" {
+		t.Fatalf("Expected 2 quarters oldest first, got %+v", earnings.History)
+	}
+	latest := earnings.History[1]
+	if latest.EPSActual.Raw != 2.18 || latest.EPSEstimate.Raw != 2.1 || latest.SurprisePercent.Raw != 0.038 {
+		t.Errorf("Unexpected latest quarter: %+v", latest)
+	}
+	if earnings.History[0].SurprisePercent != nil {
+		t.Error("Expected a nil surprise when Yahoo doesn't report it")
+	}
+	if len(earnings.Quarterly) != 1 || earnings.Quarterly[0].Revenue.Raw != 119575000000 {
+		t.Errorf("Unexpected quarterly financials: %+v", earnings.Quarterly)
+	}
+	if earnings.CurrentQuarterEstimate == nil || earnings.CurrentQuarterEstimate.Raw != 1.5 {
+		t.Error("Expected a current quarter estimate of 1.5")
+	}
+	if !earnings.NextEarningsDate.Equal(time.Unix(1714680000, 0)) {
+		t.Errorf("Unexpected next earnings date %s", earnings.NextEarningsDate)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "SPY", `{}`)
+	if _, err := ticker.FetchEarnings(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without earnings modules, got %v", err)
+	}
+}
+
+// TestFetchRecommendationTrend tests parsing the monthly analyst rating counts
+func TestFetchRecommendationTrend(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{"recommendationTrend":{"trend":[
+		{"period":"0m","strongBuy":11,"buy":21,"hold":6,"sell":0,"strongSell":0},
+		{"period":"-1m","strongBuy":10,"buy":20,"hold":8,"sell":1,"strongSell":1}],"maxAge":86400}}`)
+
+	trend, err := ticker.FetchRecommendationTrend()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(trend) != 2 {
+		t.Fatalf("Expected 2 periods, got %d", len(trend))
+	}
+	if trend[0].Period != "0m" || trend[0].StrongBuy != 11 || trend[0].Buy != 21 || trend[0].Hold != 6 {
+		t.Errorf("Unexpected current period: %+v", trend[0])
+	}
+	if trend[1].Sell != 1 || trend[1].StrongSell != 1 {
+		t.Errorf("Unexpected previous period: %+v", trend[1])
+	}
+
+	ticker = newQuoteSummaryTicker(t, "TINY", `{"recommendationTrend":{"trend":[]}}`)
+	if _, err := ticker.FetchRecommendationTrend(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without coverage, got %v", err)
+	}
+}
@@ -1,6 +1,13 @@
 package yfinance_api
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -46,6 +53,26 @@ func TestNewTicker(t *testing.T) {
 	}
 }
 
+// TestNewTickerClientSharing tests which tickers share the singleton client
+func TestNewTickerClientSharing(t *testing.T) {
+	if NewTicker("AAPL").Client != NewClient().Client {
+		t.Error("NewTicker() without options should use the singleton Client")
+	}
+
+	isolated := NewTicker("AAPL", WithTimeout(time.Second))
+	if isolated.Client == NewClient().Client {
+		t.Error("NewTicker() with options should use an isolated Client")
+	}
+	if isolated.Client.client.(*http.Client).Timeout != time.Second {
+		t.Errorf("Expected the options to be applied, got timeout %v", isolated.Client.client.(*http.Client).Timeout)
+	}
+
+	client := NewClientWithOptions()
+	if NewTickerWithClient("MSFT", client).Client != client.Client {
+		t.Error("NewTickerWithClient() should use the given Client")
+	}
+}
+
 // TestTickerGetSymbol tests the GetSymbol method
 func TestTickerGetSymbol(t *testing.T) {
 	symbol := "MSFT"
@@ -106,6 +133,24 @@ func TestFetchPriceValue(t *testing.T) {
 	}
 }
 
+// TestCurrencyInfo tests reading the currency code and symbol from the price module
+func TestCurrencyInfo(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "MC.PA", `{"price":{"symbol":"MC.PA","currency":"EUR","currencySymbol":"€"}}`)
+
+	isoCode, symbol, err := ticker.CurrencyInfo()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if isoCode != "EUR" || symbol != "€" {
+		t.Errorf("Expected EUR and €, got %s and %s", isoCode, symbol)
+	}
+
+	ticker = newQuoteSummaryTicker(t, "TINY", `{"price":{"symbol":"TINY"}}`)
+	if _, _, err := ticker.CurrencyInfo(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData, got %v", err)
+	}
+}
+
 // TestFetchHistoricalData tests fetching historical data with different parameters
 func TestFetchHistoricalData(t *testing.T) {
 	ticker := NewTicker("AAPL")
@@ -189,6 +234,110 @@ func TestFetchHistoricalData(t *testing.T) {
 	}
 }
 
+// TestFetchHistoricalWithQuery tests that a Query maps to the chart parameters, with the defaults filled in
+func TestFetchHistoricalWithQuery(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York"},
+			"timestamp":[1704205800],"indicators":{"quote":[{"open":[187.15],"high":[188.44],"low":[183.89],"close":[185.64],"volume":[82488700]}]}}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	data, err := ticker.FetchHistoricalWithQuery(Query{Start: "1704153600", End: "1704240000"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query.Get("interval") != "1d" || query.Get("period1") != "1704153600" || query.Get("period2") != "1704240000" {
+		t.Errorf("Unexpected query %v", query)
+	}
+	if query.Has("range") {
+		t.Errorf("Expected no range alongside the periods, got %q", query.Get("range"))
+	}
+	if price, ok := data["2024-01-02"]; !ok || price.Close == nil || *price.Close != 185.64 {
+		t.Errorf("Expected the close of 2024-01-02, got %+v", data)
+	}
+
+	if _, err := ticker.FetchHistoricalWithQuery(Query{Range: "5d", Interval: "1h"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query.Get("range") != "5d" || query.Get("interval") != "1h" || query.Has("period1") || query.Has("includePrePost") {
+		t.Errorf("Unexpected query %v", query)
+	}
+}
+
+// TestFetchHistoricalWithQueryPrePost tests that extended-hours bars are requested and keyed in exchange time
+func TestFetchHistoricalWithQueryPrePost(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		// 08:00 pre-market, 09:30 regular and 16:30 after-hours bars on 2024-01-02, New York time
+		fmt.Fprint(w, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York"},
+			"timestamp":[1704200400,1704205800,1704231000],
+			"indicators":{"quote":[{"open":[186,187,185],"high":[186,187,185],"low":[186,187,185],"close":[186,187,185],"volume":[1,2,3]}]}}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	data, err := ticker.FetchHistoricalWithQuery(Query{Range: "1d", Interval: "1m", IncludePrePost: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query.Get("includePrePost") != "true" {
+		t.Errorf("Expected includePrePost=true, got %v", query)
+	}
+	for _, key := range []string{"2024-01-02 08:00:00", "2024-01-02 09:30:00", "2024-01-02 16:30:00"} {
+		if _, ok := data[key]; !ok {
+			t.Errorf("Expected a bar at %s, got %v", key, data)
+		}
+	}
+}
+
+// TestFetchHistoricalDataBetween tests the conversion of the period bounds and their validation
+func TestFetchHistoricalDataBetween(t *testing.T) {
+	var query url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York"},
+			"timestamp":[1704205800],"indicators":{"quote":[{"open":[187.15],"high":[188.44],"low":[183.89],"close":[185.64],"volume":[82488700]}]}}],"error":null}}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	start := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	data, err := ticker.FetchHistoricalDataBetween(start, end, "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if query.Get("period1") != "1704153600" || query.Get("period2") != "1704240000" || query.Get("interval") != "1d" {
+		t.Errorf("Unexpected query %v", query)
+	}
+	if query.Has("range") {
+		t.Errorf("Expected no range alongside the periods, got %q", query.Get("range"))
+	}
+	if len(data) != 1 {
+		t.Errorf("Expected 1 data point, got %d", len(data))
+	}
+
+	if _, err := ticker.FetchHistoricalDataBetween(end, start, "1d"); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter when start is after end, got %v", err)
+	}
+
+	setNow(t, time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC))
+	if _, err := ticker.FetchHistoricalDataBetween(start, end, "1d"); err != nil {
+		t.Errorf("Expected an end at the close of today to be accepted, got %v", err)
+	}
+	if _, err := ticker.FetchHistoricalDataBetween(start, end.AddDate(0, 0, 1), "1d"); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("Expected ErrInvalidParameter for an end past today, got %v", err)
+	}
+}
+
 // TestFetchNews tests fetching news articles
 func TestFetchNews(t *testing.T) {
 	ticker := NewTicker("AAPL")
@@ -239,6 +388,124 @@ func TestFetchNews(t *testing.T) {
 	}
 }
 
+// TestFetchNewsPagination tests that duplicate articles are dropped and more are requested to fill the page
+func TestFetchNewsPagination(t *testing.T) {
+	// Yahoo repeats article b, so the first four entries hold only three articles
+	articles := []string{"a", "b", "b", "c", "d", "e"}
+	var newsCounts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		newsCount := r.URL.Query().Get("newsCount")
+		newsCounts = append(newsCounts, newsCount)
+		if q := r.URL.Query().Get("q"); q != "AAPL" {
+			t.Errorf("Expected q=AAPL, got %q", q)
+		}
+
+		var n int
+		fmt.Sscan(newsCount, &n)
+		items := make([]map[string]interface{}, 0, n)
+		for _, uuid := range articles[:min(n, len(articles))] {
+			items = append(items, map[string]interface{}{"uuid": uuid, "link": "https://example.com/" + uuid,
+				"thumbnail": map[string]interface{}{"resolutions": []map[string]interface{}{{"url": "https://img/" + uuid, "width": 140}}}})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"news": items})
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	news, err := ticker.FetchNews(2, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(news) != 2 || news[0].UUID != "c" || news[1].UUID != "d" {
+		t.Errorf("Expected articles c and d, got %+v", news)
+	}
+	if !reflect.DeepEqual(newsCounts, []string{"4", "5"}) {
+		t.Errorf("Expected newsCount 4 then 5, got %v", newsCounts)
+	}
+	if thumbnail, ok := news[0].GetBestThumbnail(100); !ok || thumbnail.URL != "https://img/c" {
+		t.Errorf("Expected the thumbnail to be decoded, got %+v", news[0].Thumbnail)
+	}
+
+	// Past the last article, the page is empty and no more requests are made than needed
+	newsCounts = nil
+	if news, err := ticker.FetchNews(10, 10); err != nil || len(news) != 0 {
+		t.Errorf("Expected no articles past the end, got %+v (%v)", news, err)
+	}
+	if len(newsCounts) != 1 {
+		t.Errorf("Expected a single request once Yahoo runs out of articles, got %v", newsCounts)
+	}
+}
+
+// TestNewsKey tests that articles without a UUID or link are told apart by title and publish time
+func TestNewsKey(t *testing.T) {
+	first := NewsItem{Title: "Earnings beat", ProviderPublishTime: 1700000000}
+	second := NewsItem{Title: "Guidance raised", ProviderPublishTime: 1700000000}
+	if newsKey(first) == newsKey(second) {
+		t.Errorf("Expected distinct keys for distinct articles, got %q", newsKey(first))
+	}
+	if newsKey(first) != newsKey(NewsItem{Title: "Earnings beat", ProviderPublishTime: 1700000000}) {
+		t.Error("Expected the same key for a repeated article")
+	}
+	if newsKey(NewsItem{UUID: "a", Title: "x"}) != newsKey(NewsItem{UUID: "a", Title: "y"}) {
+		t.Error("Expected the UUID to take precedence")
+	}
+}
+
+// TestFetchNewsSince tests that articles come newest first and older ones are filtered out
+func TestFetchNewsSince(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"news":[
+			{"uuid":"old","providerPublishTime":1704200000},
+			{"uuid":"newest","providerPublishTime":1704300000},
+			{"uuid":"new","providerPublishTime":1704250000}]}`)
+	}))
+	defer server.Close()
+	setBaseUrl(t, server.URL)
+
+	ticker := (&YFinanceAPI{Client: newTestClient()}).InstantiateTicker("AAPL")
+	news, err := ticker.FetchNews(3, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(news) != 3 || news[0].UUID != "newest" || news[2].UUID != "old" {
+		t.Errorf("Expected the articles newest first, got %+v", news)
+	}
+	if want := time.Unix(1704300000, 0); !news[0].PublishedAt().Equal(want) {
+		t.Errorf("Expected a publish time of %v, got %v", want, news[0].PublishedAt())
+	}
+	if !(NewsItem{}).PublishedAt().IsZero() {
+		t.Error("Expected the zero time without a publish time")
+	}
+
+	recent, err := ticker.FetchNewsSince(time.Unix(1704200000, 0), 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(recent) != 2 || recent[0].UUID != "newest" || recent[1].UUID != "new" {
+		t.Errorf("Expected only the articles after the last poll, got %+v", recent)
+	}
+}
+
+// TestGetBestThumbnail tests that the smallest resolution wide enough is picked, falling back to the widest
+func TestGetBestThumbnail(t *testing.T) {
+	item := NewsItem{Thumbnail: &NewsThumbnail{Resolutions: []ThumbnailResolution{
+		{URL: "original", Width: 1200},
+		{URL: "small", Width: 140},
+		{URL: "medium", Width: 600},
+	}}}
+
+	testCases := map[int]string{0: "small", 140: "small", 300: "medium", 1200: "original", 2000: "original"}
+	for minWidth, expected := range testCases {
+		if got, ok := item.GetBestThumbnail(minWidth); !ok || got.URL != expected {
+			t.Errorf("minWidth %d: expected %s, got %+v", minWidth, expected, got)
+		}
+	}
+	if _, ok := (NewsItem{}).GetBestThumbnail(0); ok {
+		t.Error("Expected no thumbnail for an article without one")
+	}
+}
+
 // TestFetchNewsAlternative tests the alternative news fetching method
 func TestFetchNewsAlternative(t *testing.T) {
 	ticker := NewTicker("AAPL")
@@ -307,7 +574,26 @@ func TestTransformHistoricalData(t *testing.T) {
 						Close  []*float64 `json:"close"`
 						Volume []*int64   `json:"volume"`
 					} `json:"quote"`
+					Adjclose []struct {
+						Adjclose []*float64 `json:"adjclose"`
+					} `json:"adjclose"`
 				} `json:"indicators"`
+				Events struct {
+					Dividends map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"dividends"`
+					CapitalGains map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"capitalGains"`
+					Splits map[string]struct {
+						Date        int64   `json:"date"`
+						Numerator   float64 `json:"numerator"`
+						Denominator float64 `json:"denominator"`
+						SplitRatio  string  `json:"splitRatio"`
+					} `json:"splits"`
+				} `json:"events"` // Only present when requested with events=div, splits or capitalGains
 			} `json:"result"`
 			Error interface{} `json:"error"`
 		}{
@@ -358,7 +644,26 @@ func TestTransformHistoricalData(t *testing.T) {
 						Close  []*float64 `json:"close"`
 						Volume []*int64   `json:"volume"`
 					} `json:"quote"`
+					Adjclose []struct {
+						Adjclose []*float64 `json:"adjclose"`
+					} `json:"adjclose"`
 				} `json:"indicators"`
+				Events struct {
+					Dividends map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"dividends"`
+					CapitalGains map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"capitalGains"`
+					Splits map[string]struct {
+						Date        int64   `json:"date"`
+						Numerator   float64 `json:"numerator"`
+						Denominator float64 `json:"denominator"`
+						SplitRatio  string  `json:"splitRatio"`
+					} `json:"splits"`
+				} `json:"events"` // Only present when requested with events=div, splits or capitalGains
 			}{
 				{
 					Timestamp: []int64{1640995200, 1641081600}, // Two timestamps
@@ -370,6 +675,9 @@ func TestTransformHistoricalData(t *testing.T) {
 							Close  []*float64 `json:"close"`
 							Volume []*int64   `json:"volume"`
 						} `json:"quote"`
+						Adjclose []struct {
+							Adjclose []*float64 `json:"adjclose"`
+						} `json:"adjclose"`
 					}{
 						Quote: []struct {
 							Open   []*float64 `json:"open"`
@@ -517,7 +825,26 @@ func BenchmarkTransformHistoricalData(b *testing.B) {
 						Close  []*float64 `json:"close"`
 						Volume []*int64   `json:"volume"`
 					} `json:"quote"`
+					Adjclose []struct {
+						Adjclose []*float64 `json:"adjclose"`
+					} `json:"adjclose"`
 				} `json:"indicators"`
+				Events struct {
+					Dividends map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"dividends"`
+					CapitalGains map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"capitalGains"`
+					Splits map[string]struct {
+						Date        int64   `json:"date"`
+						Numerator   float64 `json:"numerator"`
+						Denominator float64 `json:"denominator"`
+						SplitRatio  string  `json:"splitRatio"`
+					} `json:"splits"`
+				} `json:"events"` // Only present when requested with events=div, splits or capitalGains
 			} `json:"result"`
 			Error interface{} `json:"error"`
 		}{
@@ -568,7 +895,26 @@ func BenchmarkTransformHistoricalData(b *testing.B) {
 						Close  []*float64 `json:"close"`
 						Volume []*int64   `json:"volume"`
 					} `json:"quote"`
+					Adjclose []struct {
+						Adjclose []*float64 `json:"adjclose"`
+					} `json:"adjclose"`
 				} `json:"indicators"`
+				Events struct {
+					Dividends map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"dividends"`
+					CapitalGains map[string]struct {
+						Amount float64 `json:"amount"`
+						Date   int64   `json:"date"`
+					} `json:"capitalGains"`
+					Splits map[string]struct {
+						Date        int64   `json:"date"`
+						Numerator   float64 `json:"numerator"`
+						Denominator float64 `json:"denominator"`
+						SplitRatio  string  `json:"splitRatio"`
+					} `json:"splits"`
+				} `json:"events"` // Only present when requested with events=div, splits or capitalGains
 			}{
 				{
 					Timestamp: timestamps,
@@ -580,6 +926,9 @@ func BenchmarkTransformHistoricalData(b *testing.B) {
 							Close  []*float64 `json:"close"`
 							Volume []*int64   `json:"volume"`
 						} `json:"quote"`
+						Adjclose []struct {
+							Adjclose []*float64 `json:"adjclose"`
+						} `json:"adjclose"`
 					}{
 						Quote: []struct {
 							Open   []*float64 `json:"open"`
@@ -608,6 +957,32 @@ func BenchmarkTransformHistoricalData(b *testing.B) {
 	}
 }
 
+// BenchmarkTransformLargeHistoricalData measures the allocations of transforming a long hourly series
+func BenchmarkTransformLargeHistoricalData(b *testing.B) {
+	const points = 5000
+	var mockResponse YahooHistoryResponse
+	mockResponse.Chart.Result = appendZero(mockResponse.Chart.Result)
+	result := &mockResponse.Chart.Result[0]
+	result.Indicators.Quote = appendZero(result.Indicators.Quote)
+	quote := &result.Indicators.Quote[0]
+
+	baseTime := time.Date(2020, 1, 2, 14, 30, 0, 0, time.UTC).Unix()
+	for i := 0; i < points; i++ {
+		result.Timestamp = append(result.Timestamp, baseTime+int64(i*3600)) // Every hour
+		quote.Open = append(quote.Open, floatPtr(150.0+float64(i)))
+		quote.High = append(quote.High, floatPtr(155.0+float64(i)))
+		quote.Low = append(quote.Low, floatPtr(149.0+float64(i)))
+		quote.Close = append(quote.Close, floatPtr(154.0+float64(i)))
+		quote.Volume = append(quote.Volume, int64Ptr(1000000+int64(i*1000)))
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		transformHistoricalData(mockResponse, "1h")
+	}
+}
+
 // TestFetchFinancialData tests fetching comprehensive financial data
 func TestFetchFinancialData(t *testing.T) {
 	ticker := NewTicker("AAPL")
@@ -629,6 +1004,31 @@ func TestFetchFinancialData(t *testing.T) {
 	}
 }
 
+// TestFinancialDataSections tests that the sections of a fund without statements are reported as missing
+func TestFinancialDataSections(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "SPY", `{"defaultKeyStatistics":{"beta":{"raw":1}},"summaryDetail":{"trailingPE":{"raw":25}},
+		"incomeStatementHistory":{"incomeStatementHistory":[]}}`)
+
+	data, err := ticker.FetchFinancialData()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := data.AvailableSections(); !reflect.DeepEqual(got, []FinancialSection{SectionKeyStatistics, SectionSummaryDetail}) {
+		t.Errorf("Unexpected available sections %v", got)
+	}
+	expectedMissing := []FinancialSection{SectionFinancialData, SectionIncomeStatement, SectionBalanceSheet, SectionCashFlow}
+	if got := data.MissingSections(); !reflect.DeepEqual(got, expectedMissing) {
+		t.Errorf("Expected missing sections %v, got %v", expectedMissing, got)
+	}
+	if !data.HasSection(SectionSummaryDetail) || data.HasSection(SectionIncomeStatement) {
+		t.Error("Expected summaryDetail only to be present among the two")
+	}
+	if got := (FinancialData{}).MissingSections(); len(got) != len(financialSections) {
+		t.Errorf("Expected every section to be missing from an empty FinancialData, got %v", got)
+	}
+}
+
 // TestFetchFinancialRatios tests fetching financial ratios
 func TestFetchFinancialRatios(t *testing.T) {
 	ticker := NewTicker("AAPL")
@@ -904,6 +1304,72 @@ func TestDividendInfoStructure(t *testing.T) {
 	}
 }
 
+// TestPriceValueHelpers tests the nil-safe accessors of PriceValue
+func TestPriceValueHelpers(t *testing.T) {
+	var missing *PriceValue
+	if missing.Float() != 0 || missing.String() != "" || !missing.IsZero() {
+		t.Errorf("Expected 0, \"\" and zero for nil, got %v, %q and %v", missing.Float(), missing.String(), missing.IsZero())
+	}
+
+	testCases := []struct {
+		value  *PriceValue
+		float  float64
+		string string
+		zero   bool
+	}{
+		{value: &PriceValue{Raw: 189.84, Fmt: "189.84"}, float: 189.84, string: "189.84"},
+		{value: &PriceValue{Raw: 2.9e12, Fmt: "2.9T", LongFmt: "2,900,000,000,000"}, float: 2.9e12, string: "2.9T"},
+		{value: &PriceValue{Raw: 0.0052}, float: 0.0052, string: "0.0052"},
+		{value: &PriceValue{Fmt: "0.00"}, string: "0.00"},
+		{value: &PriceValue{}, string: "0", zero: true},
+	}
+	for _, tc := range testCases {
+		if got := tc.value.Float(); got != tc.float {
+			t.Errorf("Float() of %#v = %v, expected %v", *tc.value, got, tc.float)
+		}
+		if got := tc.value.String(); got != tc.string {
+			t.Errorf("String() of %#v = %q, expected %q", *tc.value, got, tc.string)
+		}
+		if got := tc.value.IsZero(); got != tc.zero {
+			t.Errorf("IsZero() of %#v = %v, expected %v", *tc.value, got, tc.zero)
+		}
+	}
+}
+
+// TestPriceValueAsTime tests converting epoch PriceValues to times and the date heuristic
+func TestPriceValueAsTime(t *testing.T) {
+	exDate := &PriceValue{Raw: 1715299200, Fmt: "2024-05-10"}
+	if !exDate.AsTime().Equal(time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected time %s", exDate.AsTime())
+	}
+	var missing *PriceValue
+	if !missing.AsTime().IsZero() || !(&PriceValue{}).AsTime().IsZero() {
+		t.Error("Expected the zero time for nil and zero values")
+	}
+
+	testCases := []struct {
+		value    *PriceValue
+		expected bool
+	}{
+		{value: exDate, expected: true},
+		{value: &PriceValue{Raw: 1715299200}, expected: true},
+		{value: &PriceValue{Raw: 0.88, Fmt: "0.88"}, expected: false},
+		{value: &PriceValue{Raw: 1500000000, Fmt: "1.5B"}, expected: false},
+		{value: &PriceValue{Raw: 3000000000000}, expected: false},
+		{value: nil, expected: false},
+	}
+	for _, tc := range testCases {
+		if got := tc.value.IsDate(); got != tc.expected {
+			t.Errorf("IsDate(%+v) = %v, expected %v", tc.value, got, tc.expected)
+		}
+	}
+
+	dividendInfo := DividendInfo{ExDividendDate: exDate}
+	if !dividendInfo.ExDividendDateTime().Equal(exDate.AsTime()) || !dividendInfo.DividendDateTime().IsZero() {
+		t.Errorf("Unexpected dividend dates %s and %s", dividendInfo.ExDividendDateTime(), dividendInfo.DividendDateTime())
+	}
+}
+
 // TestNonDividendStock tests dividend functionality with non-dividend paying stock
 func TestNonDividendStock(t *testing.T) {
 	// Test with a stock that typically doesn't pay dividends
@@ -930,6 +1396,128 @@ func TestNonDividendStock(t *testing.T) {
 	}
 }
 
+// TestFetchStatementHistory tests that every reported period is returned with its end date, most recent first
+func TestFetchStatementHistory(t *testing.T) {
+	ticker := newQuoteSummaryTicker(t, "AAPL", `{
+		"incomeStatementHistory":{"incomeStatementHistory":[
+			{"endDate":{"raw":1727654400,"fmt":"2024-09-30"},"totalRevenue":{"raw":391035000000},"netIncome":{"raw":93736000000}},
+			{"endDate":{"raw":1696032000,"fmt":"2023-09-30"},"totalRevenue":{"raw":383285000000},"netIncome":{"raw":96995000000}}]},
+		"balanceSheetHistory":{"balanceSheetStatements":[
+			{"endDate":{"raw":1727654400,"fmt":"2024-09-30"},"totalAssets":{"raw":364980000000}}]},
+		"cashflowStatementHistory":{"cashflowStatements":[]}}`)
+
+	incomes, err := ticker.FetchIncomeStatementHistory()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(incomes) != 2 {
+		t.Fatalf("Expected 2 income statements, got %d", len(incomes))
+	}
+	if !incomes[0].EndDate.Equal(time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)) || incomes[0].TotalRevenue.Raw != 391035000000 {
+		t.Errorf("Unexpected latest income statement: %+v", incomes[0])
+	}
+	if !incomes[1].EndDate.Equal(time.Date(2023, 9, 30, 0, 0, 0, 0, time.UTC)) || incomes[1].NetIncome.Raw != 96995000000 {
+		t.Errorf("Unexpected previous income statement: %+v", incomes[1])
+	}
+
+	balances, err := ticker.FetchBalanceSheetHistory()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(balances) != 1 || balances[0].TotalAssets.Raw != 364980000000 || balances[0].EndDate.IsZero() {
+		t.Errorf("Unexpected balance sheets: %+v", balances)
+	}
+
+	if _, err := ticker.FetchCashFlowHistory(); !errors.Is(err, ErrNoData) {
+		t.Errorf("Expected ErrNoData without cash flow statements, got %v", err)
+	}
+}
+
+// TestFetchWithFakeDoer tests the Fetch methods against canned responses, without any network access
+func TestFetchWithFakeDoer(t *testing.T) {
+	testCases := []struct {
+		name    string
+		fixture string
+		path    string
+		check   func(ticker *Ticker) error
+	}{
+		{
+			name:    "Information",
+			fixture: `{"quoteSummary":{"result":[{"price":{"symbol":"AAPL","regularMarketPrice":{"raw":189.84,"fmt":"189.84"},"currency":"USD"}}],"error":null}}`,
+			path:    "/v10/finance/quoteSummary/AAPL",
+			check: func(ticker *Ticker) error {
+				info, err := ticker.FetchInformation()
+				if err == nil && (info.RegularMarketPrice == nil || info.RegularMarketPrice.Raw != 189.84) {
+					err = fmt.Errorf("unexpected price %+v", info.RegularMarketPrice)
+				}
+				return err
+			},
+		},
+		{
+			name:    "FastInfo",
+			fixture: `{"quoteResponse":{"result":[{"symbol":"AAPL","regularMarketPrice":189.84}],"error":null}}`,
+			path:    "/v7/finance/quote",
+			check: func(ticker *Ticker) error {
+				info, err := ticker.FetchFastInfo()
+				if err == nil && info.LastPrice != 189.84 {
+					err = fmt.Errorf("unexpected last price %v", info.LastPrice)
+				}
+				return err
+			},
+		},
+		{
+			name: "HistoricalData",
+			fixture: `{"chart":{"result":[{"meta":{"exchangeTimezoneName":"America/New_York"},"timestamp":[1704205800],
+				"indicators":{"quote":[{"open":[187.15],"high":[188.44],"low":[183.89],"close":[185.64],"volume":[82488700]}]}}],"error":null}}`,
+			path: "/v8/finance/chart/AAPL",
+			check: func(ticker *Ticker) error {
+				data, err := ticker.FetchHistoricalData("5d", "1d", "", "")
+				if err == nil && len(data) != 1 {
+					err = fmt.Errorf("expected 1 data point, got %d", len(data))
+				}
+				return err
+			},
+		},
+		{
+			name:    "OptionExpirations",
+			fixture: `{"optionChain":{"result":[{"underlyingSymbol":"AAPL","expirationDates":[1718928000,1719532800],"options":[]}],"error":null}}`,
+			path:    "/v7/finance/options/AAPL",
+			check: func(ticker *Ticker) error {
+				expirations, err := ticker.FetchOptionExpirations()
+				if err == nil && len(expirations) != 2 {
+					err = fmt.Errorf("expected 2 expirations, got %d", len(expirations))
+				}
+				return err
+			},
+		},
+		{
+			name:    "UnknownSymbol",
+			fixture: `{"quoteSummary":{"result":null,"error":{"code":"Not Found","description":"Quote not found for symbol: AAPL"}}}`,
+			path:    "/v10/finance/quoteSummary/AAPL",
+			check: func(ticker *Ticker) error {
+				if _, err := ticker.FetchKeyStatistics(); !errors.Is(err, ErrSymbolNotFound) {
+					return fmt.Errorf("expected ErrSymbolNotFound, got %v", err)
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			doer := &fakeDoer{body: tc.fixture}
+			ticker := (&YFinanceAPI{Client: newTestClient(WithDoer(doer))}).InstantiateTicker("AAPL")
+
+			if err := tc.check(ticker); err != nil {
+				t.Fatal(err)
+			}
+			if len(doer.requests) != 1 || doer.requests[0].URL.Path != tc.path {
+				t.Errorf("Expected a single request to %s, got %d", tc.path, len(doer.requests))
+			}
+		})
+	}
+}
+
 // Benchmark dividend functions
 func BenchmarkFetchDividendInfo(b *testing.B) {
 	ticker := NewTicker("AAPL")
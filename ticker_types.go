@@ -1,5 +1,12 @@
 package yfinance_api
 
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
 type YahooInfoResponse struct {
 	QuoteSummary struct {
 		Result []struct {
@@ -16,6 +23,69 @@ type PriceValue struct {
 	LongFmt string  `json:"longFmt,omitempty"`
 }
 
+// UnmarshalJSON accepts both the {"raw": ..., "fmt": ...} objects of quoteSummary
+// and the plain numbers returned by the quote endpoints
+func (p *PriceValue) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] != '{' && data[0] != 'n' {
+		var raw float64
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		*p = PriceValue{Raw: raw}
+		return nil
+	}
+
+	type priceValue PriceValue
+	return json.Unmarshal(data, (*priceValue)(p))
+}
+
+// Float returns Raw, or 0 when p is nil, sparing callers the nil check for optional fields
+func (p *PriceValue) Float() float64 {
+	if p == nil {
+		return 0
+	}
+	return p.Raw
+}
+
+// String returns Fmt, or Raw formatted without trailing zeros when Yahoo sent no Fmt,
+// and an empty string when p is nil
+func (p *PriceValue) String() string {
+	if p == nil {
+		return ""
+	}
+	if p.Fmt != "" {
+		return p.Fmt
+	}
+	return strconv.FormatFloat(p.Raw, 'f', -1, 64)
+}
+
+// IsZero reports whether p is nil or holds neither a value nor a formatted text
+func (p *PriceValue) IsZero() bool {
+	return p == nil || *p == PriceValue{}
+}
+
+// AsTime interprets Raw as a Unix timestamp in seconds, as Yahoo uses for dates such as ExDividendDate.
+// It returns the zero time.Time when p is nil or Raw is zero, which callers can detect with IsZero.
+func (p *PriceValue) AsTime() time.Time {
+	if p == nil || p.Raw == 0 {
+		return time.Time{}
+	}
+	return time.Unix(int64(p.Raw), 0)
+}
+
+// IsDate reports whether p looks like a date rather than an amount. Yahoo formats dates as YYYY-MM-DD,
+// so Fmt decides when present; otherwise Raw must be a whole number of seconds between 1980 and 2100.
+func (p *PriceValue) IsDate() bool {
+	if p == nil {
+		return false
+	}
+	if p.Fmt != "" {
+		_, err := time.Parse("2006-01-02", p.Fmt)
+		return err == nil
+	}
+	return p.Raw == math.Trunc(p.Raw) && p.Raw >= 315532800 && p.Raw < 4102444800
+}
+
 // YahooTickerInfo --> Struct to hold key metadata about the ticker
 type YahooTickerInfo struct {
 	MaxAge                     int         `json:"maxAge"`
@@ -72,12 +142,42 @@ type PriceData struct {
 	Volume *int64   `json:"volume"`
 }
 
+// DividendEvent is a dividend paid per share, dated on its ex-dividend date
+type DividendEvent struct {
+	Date   time.Time `json:"date"`
+	Amount float64   `json:"amount"`
+}
+
+// CapitalGainEvent is a capital gains distribution per share of a fund, dated on its ex-date
+type CapitalGainEvent struct {
+	Date   time.Time `json:"date"`
+	Amount float64   `json:"amount"`
+}
+
+// SplitEvent is a stock split, where Numerator new shares replace Denominator old ones (4 and 1 for a 4:1 split)
+type SplitEvent struct {
+	Date        time.Time `json:"date"`
+	Numerator   int       `json:"numerator"`
+	Denominator int       `json:"denominator"`
+	SplitRatio  string    `json:"splitRatio"` // As reported by Yahoo, e.g. "4:1"
+}
+
+// HistoryWithEvents is a price series together with the dividends and splits that occurred over it
+type HistoryWithEvents struct {
+	Candles   []Candle        `json:"candles"`
+	Dividends []DividendEvent `json:"dividends"`
+	Splits    []SplitEvent    `json:"splits"`
+}
+
 // Query represents the query parameters for historical data requests
 type Query struct {
 	Range    string `json:"range"`
 	Interval string `json:"interval"`
-	Start    string `json:"start"`
-	End      string `json:"end"`
+	Start    string `json:"start"` // Unix timestamp sent as period1, optional
+	End      string `json:"end"`   // Unix timestamp sent as period2, optional
+	// IncludePrePost requests the pre-market and after-hours bars of intraday intervals, keyed by their
+	// exchange-local time like the regular bars
+	IncludePrePost bool `json:"includePrePost"`
 }
 
 // SetDefault sets default values for the query parameters
@@ -146,7 +246,26 @@ type YahooHistoryResponse struct {
 					Close  []*float64 `json:"close"`
 					Volume []*int64   `json:"volume"`
 				} `json:"quote"`
+				Adjclose []struct {
+					Adjclose []*float64 `json:"adjclose"`
+				} `json:"adjclose"`
 			} `json:"indicators"`
+			Events struct {
+				Dividends map[string]struct {
+					Amount float64 `json:"amount"`
+					Date   int64   `json:"date"`
+				} `json:"dividends"`
+				CapitalGains map[string]struct {
+					Amount float64 `json:"amount"`
+					Date   int64   `json:"date"`
+				} `json:"capitalGains"`
+				Splits map[string]struct {
+					Date        int64   `json:"date"`
+					Numerator   float64 `json:"numerator"`
+					Denominator float64 `json:"denominator"`
+					SplitRatio  string  `json:"splitRatio"`
+				} `json:"splits"`
+			} `json:"events"` // Only present when requested with events=div, splits or capitalGains
 		} `json:"result"`
 		Error interface{} `json:"error"`
 	} `json:"chart"`
@@ -154,21 +273,57 @@ type YahooHistoryResponse struct {
 
 // NewsItem represents a single news article from Yahoo Finance
 type NewsItem struct {
-	UUID                string `json:"uuid"`
-	Title               string `json:"title"`
-	Publisher           string `json:"publisher"`
-	Link                string `json:"link"`
-	ProviderPublishTime int64  `json:"providerPublishTime"`
-	Type                string `json:"type"`
-	Thumbnail           *struct {
-		Resolutions []struct {
-			URL    string `json:"url"`
-			Width  int    `json:"width"`
-			Height int    `json:"height"`
-			Tag    string `json:"tag"`
-		} `json:"resolutions"`
-	} `json:"thumbnail,omitempty"`
-	RelatedTickers []string `json:"relatedTickers"`
+	UUID                string         `json:"uuid"`
+	Title               string         `json:"title"`
+	Publisher           string         `json:"publisher"`
+	Link                string         `json:"link"`
+	ProviderPublishTime int64          `json:"providerPublishTime"`
+	Type                string         `json:"type"`
+	Thumbnail           *NewsThumbnail `json:"thumbnail,omitempty"`
+	RelatedTickers      []string       `json:"relatedTickers"`
+}
+
+// PublishedAt returns ProviderPublishTime as a time, or the zero time when Yahoo didn't send one
+func (n NewsItem) PublishedAt() time.Time {
+	if n.ProviderPublishTime == 0 {
+		return time.Time{}
+	}
+	return time.Unix(n.ProviderPublishTime, 0).UTC()
+}
+
+// NewsThumbnail lists the sizes a news article's thumbnail is available in
+type NewsThumbnail struct {
+	Resolutions []ThumbnailResolution `json:"resolutions"`
+}
+
+// ThumbnailResolution is one size of a news thumbnail
+type ThumbnailResolution struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Tag    string `json:"tag"` // e.g. "original" or "140x140"
+}
+
+// GetBestThumbnail returns the smallest thumbnail resolution at least minWidth pixels wide, or the widest one
+// when none is that wide. ok is false when the article has no thumbnail.
+func (n NewsItem) GetBestThumbnail(minWidth int) (resolution ThumbnailResolution, ok bool) {
+	if n.Thumbnail == nil {
+		return ThumbnailResolution{}, false
+	}
+
+	var widest ThumbnailResolution
+	for _, candidate := range n.Thumbnail.Resolutions {
+		if candidate.Width >= minWidth && (!ok || candidate.Width < resolution.Width) {
+			resolution, ok = candidate, true
+		}
+		if widest.URL == "" || candidate.Width > widest.Width {
+			widest = candidate
+		}
+	}
+	if !ok && widest.URL != "" {
+		return widest, true
+	}
+	return resolution, ok
 }
 
 // YahooNewsResponse represents the response from Yahoo Finance news API
@@ -213,6 +368,9 @@ type FinancialRatios struct {
 	BookValuePerShare *PriceValue `json:"bookValuePerShare"`
 	DividendRate      *PriceValue `json:"dividendRate"`
 	DividendYield     *PriceValue `json:"dividendYield"`
+
+	// Currency the company reports its financials in (e.g. "USD", "JPY")
+	FinancialCurrency string `json:"financialCurrency"`
 }
 
 // FinancialSummary represents key financial metrics summary
@@ -234,6 +392,7 @@ type FinancialSummary struct {
 
 // IncomeStatement represents income statement data
 type IncomeStatement struct {
+	EndDate          time.Time   `json:"endDate"` // End of the fiscal year the statement covers
 	TotalRevenue     *PriceValue `json:"totalRevenue"`
 	GrossProfit      *PriceValue `json:"grossProfit"`
 	OperatingIncome  *PriceValue `json:"operatingIncome"`
@@ -245,6 +404,7 @@ type IncomeStatement struct {
 
 // BalanceSheet represents balance sheet data
 type BalanceSheet struct {
+	EndDate           time.Time   `json:"endDate"` // Date of the balance sheet
 	TotalAssets       *PriceValue `json:"totalAssets"`
 	TotalLiabilities  *PriceValue `json:"totalLiabilities"`
 	TotalEquity       *PriceValue `json:"totalStockholderEquity"`
@@ -257,6 +417,7 @@ type BalanceSheet struct {
 
 // CashFlow represents cash flow statement data
 type CashFlow struct {
+	EndDate             time.Time   `json:"endDate"` // End of the fiscal year the statement covers
 	OperatingCashFlow   *PriceValue `json:"operatingCashFlow"`
 	FreeCashFlow        *PriceValue `json:"freeCashFlow"`
 	CapitalExpenditures *PriceValue `json:"capitalExpenditures"`
@@ -264,60 +425,276 @@ type CashFlow struct {
 }
 
 // FinancialData represents comprehensive financial data for a ticker
+// Statement values are expressed in Currency, the company's reporting currency.
 type FinancialData struct {
+	Currency        string           `json:"currency"`
 	Ratios          FinancialRatios  `json:"ratios"`
 	Summary         FinancialSummary `json:"summary"`
 	IncomeStatement IncomeStatement  `json:"incomeStatement"`
 	BalanceSheet    BalanceSheet     `json:"balanceSheet"`
 	CashFlow        CashFlow         `json:"cashFlow"`
+	sections        []FinancialSection
+}
+
+// FinancialSection is a quoteSummary module behind FinancialData. Funds and indices lack most of them,
+// in which case the matching parts of FinancialData are left empty.
+type FinancialSection string
+
+const (
+	SectionKeyStatistics   FinancialSection = "defaultKeyStatistics"
+	SectionFinancialData   FinancialSection = "financialData"
+	SectionSummaryDetail   FinancialSection = "summaryDetail"
+	SectionIncomeStatement FinancialSection = "incomeStatementHistory"
+	SectionBalanceSheet    FinancialSection = "balanceSheetHistory"
+	SectionCashFlow        FinancialSection = "cashflowStatementHistory"
+)
+
+// financialSections lists every section requested by FetchFinancialData, in request order
+var financialSections = []FinancialSection{
+	SectionKeyStatistics, SectionFinancialData, SectionSummaryDetail,
+	SectionIncomeStatement, SectionBalanceSheet, SectionCashFlow,
+}
+
+// AvailableSections returns the sections Yahoo returned data for, so that code handling stocks, funds
+// and indices alike can branch on what is present instead of checking every field for nil
+func (f FinancialData) AvailableSections() []FinancialSection {
+	return append([]FinancialSection{}, f.sections...)
+}
+
+// MissingSections returns the sections Yahoo returned no data for
+func (f FinancialData) MissingSections() []FinancialSection {
+	missing := []FinancialSection{}
+	for _, section := range financialSections {
+		if !f.HasSection(section) {
+			missing = append(missing, section)
+		}
+	}
+	return missing
+}
+
+// HasSection reports whether Yahoo returned data for section
+func (f FinancialData) HasSection(section FinancialSection) bool {
+	for _, available := range f.sections {
+		if available == section {
+			return true
+		}
+	}
+	return false
 }
 
 // YahooFinancialResponse represents the response from Yahoo Finance financial APIs
 type YahooFinancialResponse struct {
 	QuoteSummary struct {
-		Result []struct {
-			DefaultKeyStatistics *FinancialSummary `json:"defaultKeyStatistics"`
-			FinancialData        *FinancialRatios  `json:"financialData"`
-			SummaryDetail        *struct {
-				MarketCap                    *PriceValue `json:"marketCap"`
-				ForwardPE                    *PriceValue `json:"forwardPE"`
-				TrailingPE                   *PriceValue `json:"trailingPE"`
-				PriceToSalesTrailing12Months *PriceValue `json:"priceToSalesTrailing12Months"`
-				PriceToBook                  *PriceValue `json:"priceToBook"`
-				Beta                         *PriceValue `json:"beta"`
-				DividendRate                 *PriceValue `json:"dividendRate"`
-				DividendYield                *PriceValue `json:"dividendYield"`
-			} `json:"summaryDetail"`
-			IncomeStatementHistory *struct {
-				IncomeStatementHistory []struct {
-					EndDate         *PriceValue `json:"endDate"`
-					TotalRevenue    *PriceValue `json:"totalRevenue"`
-					GrossProfit     *PriceValue `json:"grossProfit"`
-					OperatingIncome *PriceValue `json:"operatingIncome"`
-					NetIncome       *PriceValue `json:"netIncome"`
-					Ebitda          *PriceValue `json:"ebitda"`
-				} `json:"incomeStatementHistory"`
-			} `json:"incomeStatementHistory"`
-			BalanceSheetHistory *struct {
-				BalanceSheetStatements []struct {
-					EndDate                *PriceValue `json:"endDate"`
-					TotalAssets            *PriceValue `json:"totalAssets"`
-					TotalLiab              *PriceValue `json:"totalLiab"`
-					TotalStockholderEquity *PriceValue `json:"totalStockholderEquity"`
-					TotalDebt              *PriceValue `json:"totalDebt"`
-					Cash                   *PriceValue `json:"cash"`
-				} `json:"balanceSheetStatements"`
-			} `json:"balanceSheetHistory"`
-			CashflowStatementHistory *struct {
-				CashflowStatements []struct {
-					EndDate                          *PriceValue `json:"endDate"`
-					TotalCashFromOperatingActivities *PriceValue `json:"totalCashFromOperatingActivities"`
-					CapitalExpenditures              *PriceValue `json:"capitalExpenditures"`
-					FreeCashFlow                     *PriceValue `json:"freeCashFlow"`
-					DividendsPaid                    *PriceValue `json:"dividendsPaid"`
-				} `json:"cashflowStatements"`
-			} `json:"cashflowStatementHistory"`
-		} `json:"result"`
-		Error interface{} `json:"error"`
+		Result []YahooFinancialResult `json:"result"`
+		Error  interface{}            `json:"error"`
 	} `json:"quoteSummary"`
 }
+
+// YahooFinancialResult holds the quoteSummary modules used to build the financial data of a ticker
+type YahooFinancialResult struct {
+	DefaultKeyStatistics *FinancialSummary `json:"defaultKeyStatistics"`
+	FinancialData        *FinancialRatios  `json:"financialData"`
+	SummaryDetail        *struct {
+		MarketCap                    *PriceValue `json:"marketCap"`
+		ForwardPE                    *PriceValue `json:"forwardPE"`
+		TrailingPE                   *PriceValue `json:"trailingPE"`
+		PriceToSalesTrailing12Months *PriceValue `json:"priceToSalesTrailing12Months"`
+		PriceToBook                  *PriceValue `json:"priceToBook"`
+		Beta                         *PriceValue `json:"beta"`
+		DividendRate                 *PriceValue `json:"dividendRate"`
+		DividendYield                *PriceValue `json:"dividendYield"`
+	} `json:"summaryDetail"`
+	IncomeStatementHistory *struct {
+		IncomeStatementHistory []struct {
+			EndDate         *PriceValue `json:"endDate"`
+			TotalRevenue    *PriceValue `json:"totalRevenue"`
+			GrossProfit     *PriceValue `json:"grossProfit"`
+			OperatingIncome *PriceValue `json:"operatingIncome"`
+			NetIncome       *PriceValue `json:"netIncome"`
+			Ebitda          *PriceValue `json:"ebitda"`
+		} `json:"incomeStatementHistory"`
+	} `json:"incomeStatementHistory"`
+	BalanceSheetHistory *struct {
+		BalanceSheetStatements []struct {
+			EndDate                *PriceValue `json:"endDate"`
+			TotalAssets            *PriceValue `json:"totalAssets"`
+			TotalLiab              *PriceValue `json:"totalLiab"`
+			TotalStockholderEquity *PriceValue `json:"totalStockholderEquity"`
+			TotalDebt              *PriceValue `json:"totalDebt"`
+			Cash                   *PriceValue `json:"cash"`
+		} `json:"balanceSheetStatements"`
+	} `json:"balanceSheetHistory"`
+	CashflowStatementHistory *struct {
+		CashflowStatements []struct {
+			EndDate                          *PriceValue `json:"endDate"`
+			TotalCashFromOperatingActivities *PriceValue `json:"totalCashFromOperatingActivities"`
+			CapitalExpenditures              *PriceValue `json:"capitalExpenditures"`
+			FreeCashFlow                     *PriceValue `json:"freeCashFlow"`
+			DividendsPaid                    *PriceValue `json:"dividendsPaid"`
+		} `json:"cashflowStatements"`
+	} `json:"cashflowStatementHistory"`
+	AssetProfile *struct {
+		Sector              string `json:"sector"`
+		Industry            string `json:"industry"`
+		Website             string `json:"website"`
+		LongBusinessSummary string `json:"longBusinessSummary"`
+		FullTimeEmployees   *int64 `json:"fullTimeEmployees"`
+		Country             string `json:"country"`
+		City                string `json:"city"`
+		CompanyOfficers     []struct {
+			Name     string      `json:"name"`
+			Title    string      `json:"title"`
+			Age      *int        `json:"age"`
+			TotalPay *PriceValue `json:"totalPay"`
+		} `json:"companyOfficers"`
+	} `json:"assetProfile"`
+}
+
+// CompanyProfile describes a company, as reported in the assetProfile module
+type CompanyProfile struct {
+	Sector              string           `json:"sector"`
+	Industry            string           `json:"industry"`
+	Website             string           `json:"website"`
+	LongBusinessSummary string           `json:"longBusinessSummary"`
+	FullTimeEmployees   *int64           `json:"fullTimeEmployees"`
+	Country             string           `json:"country"`
+	City                string           `json:"city"`
+	Officers            []CompanyOfficer `json:"officers"`
+}
+
+// CompanyOfficer is an executive of a company
+type CompanyOfficer struct {
+	Name     string   `json:"name"`
+	Title    string   `json:"title"`
+	Age      *int     `json:"age"`      // Nil when undisclosed
+	TotalPay *float64 `json:"totalPay"` // Yearly compensation, nil when undisclosed
+}
+
+// InvolvementAreas flags the controversial business activities a company is involved in,
+// as reported in the esgScores module
+type InvolvementAreas struct {
+	Adult                bool `json:"adult"`
+	Alcoholic            bool `json:"alcoholic"`
+	AnimalTesting        bool `json:"animalTesting"`
+	Catholic             bool `json:"catholic"`
+	ControversialWeapons bool `json:"controversialWeapons"`
+	SmallArms            bool `json:"smallArms"`
+	FurLeather           bool `json:"furLeather"`
+	Gambling             bool `json:"gambling"`
+	GMO                  bool `json:"gmo"`
+	MilitaryContract     bool `json:"militaryContract"`
+	Nuclear              bool `json:"nuclear"`
+	Pesticides           bool `json:"pesticides"`
+	PalmOil              bool `json:"palmOil"`
+	Coal                 bool `json:"coal"`
+	Tobacco              bool `json:"tobacco"`
+}
+
+// ESGScores are the Sustainalytics risk scores of a company, as reported in the esgScores module.
+// Lower scores mean lower ESG risk.
+type ESGScores struct {
+	TotalEsg           *PriceValue `json:"totalEsg"`
+	EnvironmentScore   *PriceValue `json:"environmentScore"`
+	SocialScore        *PriceValue `json:"socialScore"`
+	GovernanceScore    *PriceValue `json:"governanceScore"`
+	EsgPerformance     string      `json:"esgPerformance"`     // Compared to peers, e.g. "OUT_PERF", "AVG_PERF" or "UNDER_PERF"
+	Percentile         *PriceValue `json:"percentile"`         // Rank of the total score within the peer group
+	HighestControversy *PriceValue `json:"highestControversy"` // Controversy level from 0 (none) to 5 (severe)
+	PeerGroup          string      `json:"peerGroup"`
+	RatingYear         int         `json:"ratingYear"`  // Zero when not reported
+	RatingMonth        int         `json:"ratingMonth"` // Zero when not reported
+}
+
+// Calendar represents the upcoming earnings and dividend events of a ticker.
+// Dates are zero when Yahoo doesn't report them.
+type Calendar struct {
+	NextEarningsDate time.Time   `json:"nextEarningsDate"`
+	EarningsDates    []time.Time `json:"earningsDates"` // Announced date, or the window it is expected in, oldest first
+	EarningsLow      *float64    `json:"earningsLow"`
+	EarningsHigh     *float64    `json:"earningsHigh"`
+	EarningsAverage  *float64    `json:"earningsAverage"`
+	RevenueLow       *float64    `json:"revenueLow"`
+	RevenueHigh      *float64    `json:"revenueHigh"`
+	RevenueAverage   *float64    `json:"revenueAverage"`
+	ExDividendDate   time.Time   `json:"exDividendDate"`
+	DividendDate     time.Time   `json:"dividendDate"`
+}
+
+// Earnings holds the reported and estimated earnings of a company
+type Earnings struct {
+	History                []EarningsQuarter   `json:"history"`                // Last reported quarters, oldest first
+	Quarterly              []FinancialsQuarter `json:"quarterly"`              // Revenue and earnings of the last quarters, oldest first
+	CurrentQuarterEstimate *PriceValue         `json:"currentQuarterEstimate"` // Consensus EPS of the quarter being reported next
+	NextEarningsDate       time.Time           `json:"nextEarningsDate"`       // Zero when not announced
+}
+
+// EarningsQuarter compares the reported EPS of a quarter with the analyst estimate
+type EarningsQuarter struct {
+	Quarter         time.Time   `json:"quarter"` // Last day of the fiscal quarter
+	Period          string      `json:"period"`  // Relative to the current quarter, e.g. "-1q"
+	EPSActual       *PriceValue `json:"epsActual"`
+	EPSEstimate     *PriceValue `json:"epsEstimate"`
+	EPSDifference   *PriceValue `json:"epsDifference"`
+	SurprisePercent *PriceValue `json:"surprisePercent"` // As a fraction, e.g. 0.05 for a 5% beat
+}
+
+// FinancialsQuarter is the revenue and net earnings of a fiscal quarter
+type FinancialsQuarter struct {
+	Date     string      `json:"date"` // Fiscal quarter, e.g. "4Q2023"
+	Revenue  *PriceValue `json:"revenue"`
+	Earnings *PriceValue `json:"earnings"`
+}
+
+// yahooEarnings represents the earnings and earningsHistory quoteSummary modules
+type yahooEarnings struct {
+	Earnings *struct {
+		EarningsChart struct {
+			CurrentQuarterEstimate *PriceValue  `json:"currentQuarterEstimate"`
+			EarningsDate           []PriceValue `json:"earningsDate"`
+		} `json:"earningsChart"`
+		FinancialsChart struct {
+			Quarterly []FinancialsQuarter `json:"quarterly"`
+		} `json:"financialsChart"`
+	} `json:"earnings"`
+	EarningsHistory *struct {
+		History []struct {
+			Quarter         *PriceValue `json:"quarter"`
+			Period          string      `json:"period"`
+			EPSActual       *PriceValue `json:"epsActual"`
+			EPSEstimate     *PriceValue `json:"epsEstimate"`
+			EPSDifference   *PriceValue `json:"epsDifference"`
+			SurprisePercent *PriceValue `json:"surprisePercent"`
+		} `json:"history"`
+	} `json:"earningsHistory"`
+}
+
+// RecommendationPeriod counts the analyst ratings of a month, as reported in the recommendationTrend module
+type RecommendationPeriod struct {
+	Period     string `json:"period"` // "0m" for the current month, "-1m" for the previous one...
+	StrongBuy  int    `json:"strongBuy"`
+	Buy        int    `json:"buy"`
+	Hold       int    `json:"hold"`
+	Sell       int    `json:"sell"`
+	StrongSell int    `json:"strongSell"`
+}
+
+// yahooCalendarEvents represents the calendarEvents quoteSummary module
+type yahooCalendarEvents struct {
+	Earnings struct {
+		EarningsDate    []PriceValue `json:"earningsDate"`
+		EarningsAverage *PriceValue  `json:"earningsAverage"`
+		EarningsLow     *PriceValue  `json:"earningsLow"`
+		EarningsHigh    *PriceValue  `json:"earningsHigh"`
+		RevenueAverage  *PriceValue  `json:"revenueAverage"`
+		RevenueLow      *PriceValue  `json:"revenueLow"`
+		RevenueHigh     *PriceValue  `json:"revenueHigh"`
+	} `json:"earnings"`
+	ExDividendDate *PriceValue `json:"exDividendDate"`
+	DividendDate   *PriceValue `json:"dividendDate"`
+}
+
+// OwnershipPoint is the percentage of shares held by a group of holders as of a date
+type OwnershipPoint struct {
+	Date    time.Time `json:"date"`
+	Percent float64   `json:"percent"` // Fraction of shares outstanding, e.g. 0.017 for 1.7%
+}
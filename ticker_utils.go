@@ -1,107 +1,52 @@
 package yfinance_api
 
 import (
+	"math"
+	"sort"
 	"strings"
 	"time"
 )
 
 // transformFinancialData converts Yahoo Finance API response into structured FinancialData
-func (t *Ticker) transformFinancialData(result struct {
-	DefaultKeyStatistics *FinancialSummary `json:"defaultKeyStatistics"`
-	FinancialData        *FinancialRatios  `json:"financialData"`
-	SummaryDetail        *struct {
-		MarketCap                    *PriceValue `json:"marketCap"`
-		ForwardPE                    *PriceValue `json:"forwardPE"`
-		TrailingPE                   *PriceValue `json:"trailingPE"`
-		PriceToSalesTrailing12Months *PriceValue `json:"priceToSalesTrailing12Months"`
-		PriceToBook                  *PriceValue `json:"priceToBook"`
-		Beta                         *PriceValue `json:"beta"`
-		DividendRate                 *PriceValue `json:"dividendRate"`
-		DividendYield                *PriceValue `json:"dividendYield"`
-	} `json:"summaryDetail"`
-	IncomeStatementHistory *struct {
-		IncomeStatementHistory []struct {
-			EndDate         *PriceValue `json:"endDate"`
-			TotalRevenue    *PriceValue `json:"totalRevenue"`
-			GrossProfit     *PriceValue `json:"grossProfit"`
-			OperatingIncome *PriceValue `json:"operatingIncome"`
-			NetIncome       *PriceValue `json:"netIncome"`
-			Ebitda          *PriceValue `json:"ebitda"`
-		} `json:"incomeStatementHistory"`
-	} `json:"incomeStatementHistory"`
-	BalanceSheetHistory *struct {
-		BalanceSheetStatements []struct {
-			EndDate                *PriceValue `json:"endDate"`
-			TotalAssets            *PriceValue `json:"totalAssets"`
-			TotalLiab              *PriceValue `json:"totalLiab"`
-			TotalStockholderEquity *PriceValue `json:"totalStockholderEquity"`
-			TotalDebt              *PriceValue `json:"totalDebt"`
-			Cash                   *PriceValue `json:"cash"`
-		} `json:"balanceSheetStatements"`
-	} `json:"balanceSheetHistory"`
-	CashflowStatementHistory *struct {
-		CashflowStatements []struct {
-			EndDate                          *PriceValue `json:"endDate"`
-			TotalCashFromOperatingActivities *PriceValue `json:"totalCashFromOperatingActivities"`
-			CapitalExpenditures              *PriceValue `json:"capitalExpenditures"`
-			FreeCashFlow                     *PriceValue `json:"freeCashFlow"`
-			DividendsPaid                    *PriceValue `json:"dividendsPaid"`
-		} `json:"cashflowStatements"`
-	} `json:"cashflowStatementHistory"`
-}) FinancialData {
+func (t *Ticker) transformFinancialData(result YahooFinancialResult) FinancialData {
+	currency := ""
+	if result.FinancialData != nil {
+		currency = result.FinancialData.FinancialCurrency
+	}
+
 	return FinancialData{
+		Currency:        currency,
 		Ratios:          t.extractFinancialRatios(result),
 		Summary:         t.extractFinancialSummary(result),
 		IncomeStatement: t.extractIncomeStatement(result),
 		BalanceSheet:    t.extractBalanceSheet(result),
 		CashFlow:        t.extractCashFlow(result),
+		sections:        availableSections(result),
 	}
 }
 
+// availableSections lists the modules of result that hold data, statements counting only when non-empty
+func availableSections(result YahooFinancialResult) []FinancialSection {
+	present := map[FinancialSection]bool{
+		SectionKeyStatistics:   result.DefaultKeyStatistics != nil,
+		SectionFinancialData:   result.FinancialData != nil,
+		SectionSummaryDetail:   result.SummaryDetail != nil,
+		SectionIncomeStatement: result.IncomeStatementHistory != nil && len(result.IncomeStatementHistory.IncomeStatementHistory) > 0,
+		SectionBalanceSheet:    result.BalanceSheetHistory != nil && len(result.BalanceSheetHistory.BalanceSheetStatements) > 0,
+		SectionCashFlow:        result.CashflowStatementHistory != nil && len(result.CashflowStatementHistory.CashflowStatements) > 0,
+	}
+
+	sections := []FinancialSection{}
+	for _, section := range financialSections {
+		if present[section] {
+			sections = append(sections, section)
+		}
+	}
+	return sections
+}
+
 // extractFinancialRatios extracts financial ratios from the API response
-func (t *Ticker) extractFinancialRatios(result struct {
-	DefaultKeyStatistics *FinancialSummary `json:"defaultKeyStatistics"`
-	FinancialData        *FinancialRatios  `json:"financialData"`
-	SummaryDetail        *struct {
-		MarketCap                    *PriceValue `json:"marketCap"`
-		ForwardPE                    *PriceValue `json:"forwardPE"`
-		TrailingPE                   *PriceValue `json:"trailingPE"`
-		PriceToSalesTrailing12Months *PriceValue `json:"priceToSalesTrailing12Months"`
-		PriceToBook                  *PriceValue `json:"priceToBook"`
-		Beta                         *PriceValue `json:"beta"`
-		DividendRate                 *PriceValue `json:"dividendRate"`
-		DividendYield                *PriceValue `json:"dividendYield"`
-	} `json:"summaryDetail"`
-	IncomeStatementHistory *struct {
-		IncomeStatementHistory []struct {
-			EndDate         *PriceValue `json:"endDate"`
-			TotalRevenue    *PriceValue `json:"totalRevenue"`
-			GrossProfit     *PriceValue `json:"grossProfit"`
-			OperatingIncome *PriceValue `json:"operatingIncome"`
-			NetIncome       *PriceValue `json:"netIncome"`
-			Ebitda          *PriceValue `json:"ebitda"`
-		} `json:"incomeStatementHistory"`
-	} `json:"incomeStatementHistory"`
-	BalanceSheetHistory *struct {
-		BalanceSheetStatements []struct {
-			EndDate                *PriceValue `json:"endDate"`
-			TotalAssets            *PriceValue `json:"totalAssets"`
-			TotalLiab              *PriceValue `json:"totalLiab"`
-			TotalStockholderEquity *PriceValue `json:"totalStockholderEquity"`
-			TotalDebt              *PriceValue `json:"totalDebt"`
-			Cash                   *PriceValue `json:"cash"`
-		} `json:"balanceSheetStatements"`
-	} `json:"balanceSheetHistory"`
-	CashflowStatementHistory *struct {
-		CashflowStatements []struct {
-			EndDate                          *PriceValue `json:"endDate"`
-			TotalCashFromOperatingActivities *PriceValue `json:"totalCashFromOperatingActivities"`
-			CapitalExpenditures              *PriceValue `json:"capitalExpenditures"`
-			FreeCashFlow                     *PriceValue `json:"freeCashFlow"`
-			DividendsPaid                    *PriceValue `json:"dividendsPaid"`
-		} `json:"cashflowStatements"`
-	} `json:"cashflowStatementHistory"`
-}) FinancialRatios {
+func (t *Ticker) extractFinancialRatios(result YahooFinancialResult) FinancialRatios {
 	ratios := FinancialRatios{}
 
 	// Extract from SummaryDetail
@@ -129,6 +74,7 @@ func (t *Ticker) extractFinancialRatios(result struct {
 		ratios.RevenueGrowth = result.FinancialData.RevenueGrowth
 		ratios.EarningsPerShare = result.FinancialData.EarningsPerShare
 		ratios.BookValuePerShare = result.FinancialData.BookValuePerShare
+		ratios.FinancialCurrency = result.FinancialData.FinancialCurrency
 	}
 
 	// Extract from DefaultKeyStatistics if available
@@ -145,49 +91,7 @@ func (t *Ticker) extractFinancialRatios(result struct {
 }
 
 // extractFinancialSummary extracts financial summary data from the API response
-func (t *Ticker) extractFinancialSummary(result struct {
-	DefaultKeyStatistics *FinancialSummary `json:"defaultKeyStatistics"`
-	FinancialData        *FinancialRatios  `json:"financialData"`
-	SummaryDetail        *struct {
-		MarketCap                    *PriceValue `json:"marketCap"`
-		ForwardPE                    *PriceValue `json:"forwardPE"`
-		TrailingPE                   *PriceValue `json:"trailingPE"`
-		PriceToSalesTrailing12Months *PriceValue `json:"priceToSalesTrailing12Months"`
-		PriceToBook                  *PriceValue `json:"priceToBook"`
-		Beta                         *PriceValue `json:"beta"`
-		DividendRate                 *PriceValue `json:"dividendRate"`
-		DividendYield                *PriceValue `json:"dividendYield"`
-	} `json:"summaryDetail"`
-	IncomeStatementHistory *struct {
-		IncomeStatementHistory []struct {
-			EndDate         *PriceValue `json:"endDate"`
-			TotalRevenue    *PriceValue `json:"totalRevenue"`
-			GrossProfit     *PriceValue `json:"grossProfit"`
-			OperatingIncome *PriceValue `json:"operatingIncome"`
-			NetIncome       *PriceValue `json:"netIncome"`
-			Ebitda          *PriceValue `json:"ebitda"`
-		} `json:"incomeStatementHistory"`
-	} `json:"incomeStatementHistory"`
-	BalanceSheetHistory *struct {
-		BalanceSheetStatements []struct {
-			EndDate                *PriceValue `json:"endDate"`
-			TotalAssets            *PriceValue `json:"totalAssets"`
-			TotalLiab              *PriceValue `json:"totalLiab"`
-			TotalStockholderEquity *PriceValue `json:"totalStockholderEquity"`
-			TotalDebt              *PriceValue `json:"totalDebt"`
-			Cash                   *PriceValue `json:"cash"`
-		} `json:"balanceSheetStatements"`
-	} `json:"balanceSheetHistory"`
-	CashflowStatementHistory *struct {
-		CashflowStatements []struct {
-			EndDate                          *PriceValue `json:"endDate"`
-			TotalCashFromOperatingActivities *PriceValue `json:"totalCashFromOperatingActivities"`
-			CapitalExpenditures              *PriceValue `json:"capitalExpenditures"`
-			FreeCashFlow                     *PriceValue `json:"freeCashFlow"`
-			DividendsPaid                    *PriceValue `json:"dividendsPaid"`
-		} `json:"cashflowStatements"`
-	} `json:"cashflowStatementHistory"`
-}) FinancialSummary {
+func (t *Ticker) extractFinancialSummary(result YahooFinancialResult) FinancialSummary {
 	summary := FinancialSummary{}
 
 	// Prioritize DefaultKeyStatistics
@@ -221,59 +125,12 @@ func (t *Ticker) extractFinancialSummary(result struct {
 }
 
 // extractIncomeStatement extracts the latest income statement data
-func (t *Ticker) extractIncomeStatement(result struct {
-	DefaultKeyStatistics *FinancialSummary `json:"defaultKeyStatistics"`
-	FinancialData        *FinancialRatios  `json:"financialData"`
-	SummaryDetail        *struct {
-		MarketCap                    *PriceValue `json:"marketCap"`
-		ForwardPE                    *PriceValue `json:"forwardPE"`
-		TrailingPE                   *PriceValue `json:"trailingPE"`
-		PriceToSalesTrailing12Months *PriceValue `json:"priceToSalesTrailing12Months"`
-		PriceToBook                  *PriceValue `json:"priceToBook"`
-		Beta                         *PriceValue `json:"beta"`
-		DividendRate                 *PriceValue `json:"dividendRate"`
-		DividendYield                *PriceValue `json:"dividendYield"`
-	} `json:"summaryDetail"`
-	IncomeStatementHistory *struct {
-		IncomeStatementHistory []struct {
-			EndDate         *PriceValue `json:"endDate"`
-			TotalRevenue    *PriceValue `json:"totalRevenue"`
-			GrossProfit     *PriceValue `json:"grossProfit"`
-			OperatingIncome *PriceValue `json:"operatingIncome"`
-			NetIncome       *PriceValue `json:"netIncome"`
-			Ebitda          *PriceValue `json:"ebitda"`
-		} `json:"incomeStatementHistory"`
-	} `json:"incomeStatementHistory"`
-	BalanceSheetHistory *struct {
-		BalanceSheetStatements []struct {
-			EndDate                *PriceValue `json:"endDate"`
-			TotalAssets            *PriceValue `json:"totalAssets"`
-			TotalLiab              *PriceValue `json:"totalLiab"`
-			TotalStockholderEquity *PriceValue `json:"totalStockholderEquity"`
-			TotalDebt              *PriceValue `json:"totalDebt"`
-			Cash                   *PriceValue `json:"cash"`
-		} `json:"balanceSheetStatements"`
-	} `json:"balanceSheetHistory"`
-	CashflowStatementHistory *struct {
-		CashflowStatements []struct {
-			EndDate                          *PriceValue `json:"endDate"`
-			TotalCashFromOperatingActivities *PriceValue `json:"totalCashFromOperatingActivities"`
-			CapitalExpenditures              *PriceValue `json:"capitalExpenditures"`
-			FreeCashFlow                     *PriceValue `json:"freeCashFlow"`
-			DividendsPaid                    *PriceValue `json:"dividendsPaid"`
-		} `json:"cashflowStatements"`
-	} `json:"cashflowStatementHistory"`
-}) IncomeStatement {
+func (t *Ticker) extractIncomeStatement(result YahooFinancialResult) IncomeStatement {
 	income := IncomeStatement{}
 
-	if result.IncomeStatementHistory != nil && len(result.IncomeStatementHistory.IncomeStatementHistory) > 0 {
-		// Get the most recent income statement (first in the array)
-		latest := result.IncomeStatementHistory.IncomeStatementHistory[0]
-		income.TotalRevenue = latest.TotalRevenue
-		income.GrossProfit = latest.GrossProfit
-		income.OperatingIncome = latest.OperatingIncome
-		income.NetIncome = latest.NetIncome
-		income.Ebitda = latest.Ebitda
+	// Get the most recent income statement (first in the array)
+	if history := t.extractIncomeStatementHistory(result); len(history) > 0 {
+		income = history[0]
 	}
 
 	// Add EPS data from financial ratios if available
@@ -284,60 +141,33 @@ func (t *Ticker) extractIncomeStatement(result struct {
 	return income
 }
 
+// extractIncomeStatementHistory extracts every reported income statement, most recent first
+func (t *Ticker) extractIncomeStatementHistory(result YahooFinancialResult) []IncomeStatement {
+	if result.IncomeStatementHistory == nil {
+		return nil
+	}
+
+	statements := make([]IncomeStatement, 0, len(result.IncomeStatementHistory.IncomeStatementHistory))
+	for _, statement := range result.IncomeStatementHistory.IncomeStatementHistory {
+		statements = append(statements, IncomeStatement{
+			EndDate:         statement.EndDate.AsTime(),
+			TotalRevenue:    statement.TotalRevenue,
+			GrossProfit:     statement.GrossProfit,
+			OperatingIncome: statement.OperatingIncome,
+			NetIncome:       statement.NetIncome,
+			Ebitda:          statement.Ebitda,
+		})
+	}
+	return statements
+}
+
 // extractBalanceSheet extracts the latest balance sheet data
-func (t *Ticker) extractBalanceSheet(result struct {
-	DefaultKeyStatistics *FinancialSummary `json:"defaultKeyStatistics"`
-	FinancialData        *FinancialRatios  `json:"financialData"`
-	SummaryDetail        *struct {
-		MarketCap                    *PriceValue `json:"marketCap"`
-		ForwardPE                    *PriceValue `json:"forwardPE"`
-		TrailingPE                   *PriceValue `json:"trailingPE"`
-		PriceToSalesTrailing12Months *PriceValue `json:"priceToSalesTrailing12Months"`
-		PriceToBook                  *PriceValue `json:"priceToBook"`
-		Beta                         *PriceValue `json:"beta"`
-		DividendRate                 *PriceValue `json:"dividendRate"`
-		DividendYield                *PriceValue `json:"dividendYield"`
-	} `json:"summaryDetail"`
-	IncomeStatementHistory *struct {
-		IncomeStatementHistory []struct {
-			EndDate         *PriceValue `json:"endDate"`
-			TotalRevenue    *PriceValue `json:"totalRevenue"`
-			GrossProfit     *PriceValue `json:"grossProfit"`
-			OperatingIncome *PriceValue `json:"operatingIncome"`
-			NetIncome       *PriceValue `json:"netIncome"`
-			Ebitda          *PriceValue `json:"ebitda"`
-		} `json:"incomeStatementHistory"`
-	} `json:"incomeStatementHistory"`
-	BalanceSheetHistory *struct {
-		BalanceSheetStatements []struct {
-			EndDate                *PriceValue `json:"endDate"`
-			TotalAssets            *PriceValue `json:"totalAssets"`
-			TotalLiab              *PriceValue `json:"totalLiab"`
-			TotalStockholderEquity *PriceValue `json:"totalStockholderEquity"`
-			TotalDebt              *PriceValue `json:"totalDebt"`
-			Cash                   *PriceValue `json:"cash"`
-		} `json:"balanceSheetStatements"`
-	} `json:"balanceSheetHistory"`
-	CashflowStatementHistory *struct {
-		CashflowStatements []struct {
-			EndDate                          *PriceValue `json:"endDate"`
-			TotalCashFromOperatingActivities *PriceValue `json:"totalCashFromOperatingActivities"`
-			CapitalExpenditures              *PriceValue `json:"capitalExpenditures"`
-			FreeCashFlow                     *PriceValue `json:"freeCashFlow"`
-			DividendsPaid                    *PriceValue `json:"dividendsPaid"`
-		} `json:"cashflowStatements"`
-	} `json:"cashflowStatementHistory"`
-}) BalanceSheet {
+func (t *Ticker) extractBalanceSheet(result YahooFinancialResult) BalanceSheet {
 	balance := BalanceSheet{}
 
-	if result.BalanceSheetHistory != nil && len(result.BalanceSheetHistory.BalanceSheetStatements) > 0 {
-		// Get the most recent balance sheet (first in the array)
-		latest := result.BalanceSheetHistory.BalanceSheetStatements[0]
-		balance.TotalAssets = latest.TotalAssets
-		balance.TotalLiabilities = latest.TotalLiab
-		balance.TotalEquity = latest.TotalStockholderEquity
-		balance.TotalDebt = latest.TotalDebt
-		balance.Cash = latest.Cash
+	// Get the most recent balance sheet (first in the array)
+	if history := t.extractBalanceSheetHistory(result); len(history) > 0 {
+		balance = history[0]
 	}
 
 	// Add book value per share from financial ratios if available
@@ -348,74 +178,95 @@ func (t *Ticker) extractBalanceSheet(result struct {
 	return balance
 }
 
+// extractBalanceSheetHistory extracts every reported balance sheet, most recent first
+func (t *Ticker) extractBalanceSheetHistory(result YahooFinancialResult) []BalanceSheet {
+	if result.BalanceSheetHistory == nil {
+		return nil
+	}
+
+	statements := make([]BalanceSheet, 0, len(result.BalanceSheetHistory.BalanceSheetStatements))
+	for _, statement := range result.BalanceSheetHistory.BalanceSheetStatements {
+		statements = append(statements, BalanceSheet{
+			EndDate:          statement.EndDate.AsTime(),
+			TotalAssets:      statement.TotalAssets,
+			TotalLiabilities: statement.TotalLiab,
+			TotalEquity:      statement.TotalStockholderEquity,
+			TotalDebt:        statement.TotalDebt,
+			Cash:             statement.Cash,
+		})
+	}
+	return statements
+}
+
 // extractCashFlow extracts the latest cash flow statement data
-func (t *Ticker) extractCashFlow(result struct {
-	DefaultKeyStatistics *FinancialSummary `json:"defaultKeyStatistics"`
-	FinancialData        *FinancialRatios  `json:"financialData"`
-	SummaryDetail        *struct {
-		MarketCap                    *PriceValue `json:"marketCap"`
-		ForwardPE                    *PriceValue `json:"forwardPE"`
-		TrailingPE                   *PriceValue `json:"trailingPE"`
-		PriceToSalesTrailing12Months *PriceValue `json:"priceToSalesTrailing12Months"`
-		PriceToBook                  *PriceValue `json:"priceToBook"`
-		Beta                         *PriceValue `json:"beta"`
-		DividendRate                 *PriceValue `json:"dividendRate"`
-		DividendYield                *PriceValue `json:"dividendYield"`
-	} `json:"summaryDetail"`
-	IncomeStatementHistory *struct {
-		IncomeStatementHistory []struct {
-			EndDate         *PriceValue `json:"endDate"`
-			TotalRevenue    *PriceValue `json:"totalRevenue"`
-			GrossProfit     *PriceValue `json:"grossProfit"`
-			OperatingIncome *PriceValue `json:"operatingIncome"`
-			NetIncome       *PriceValue `json:"netIncome"`
-			Ebitda          *PriceValue `json:"ebitda"`
-		} `json:"incomeStatementHistory"`
-	} `json:"incomeStatementHistory"`
-	BalanceSheetHistory *struct {
-		BalanceSheetStatements []struct {
-			EndDate                *PriceValue `json:"endDate"`
-			TotalAssets            *PriceValue `json:"totalAssets"`
-			TotalLiab              *PriceValue `json:"totalLiab"`
-			TotalStockholderEquity *PriceValue `json:"totalStockholderEquity"`
-			TotalDebt              *PriceValue `json:"totalDebt"`
-			Cash                   *PriceValue `json:"cash"`
-		} `json:"balanceSheetStatements"`
-	} `json:"balanceSheetHistory"`
-	CashflowStatementHistory *struct {
-		CashflowStatements []struct {
-			EndDate                          *PriceValue `json:"endDate"`
-			TotalCashFromOperatingActivities *PriceValue `json:"totalCashFromOperatingActivities"`
-			CapitalExpenditures              *PriceValue `json:"capitalExpenditures"`
-			FreeCashFlow                     *PriceValue `json:"freeCashFlow"`
-			DividendsPaid                    *PriceValue `json:"dividendsPaid"`
-		} `json:"cashflowStatements"`
-	} `json:"cashflowStatementHistory"`
-}) CashFlow {
-	cashflow := CashFlow{}
+func (t *Ticker) extractCashFlow(result YahooFinancialResult) CashFlow {
+	// Get the most recent cash flow statement (first in the array)
+	if history := t.extractCashFlowHistory(result); len(history) > 0 {
+		return history[0]
+	}
 
-	if result.CashflowStatementHistory != nil && len(result.CashflowStatementHistory.CashflowStatements) > 0 {
-		// Get the most recent cash flow statement (first in the array)
-		latest := result.CashflowStatementHistory.CashflowStatements[0]
-		cashflow.OperatingCashFlow = latest.TotalCashFromOperatingActivities
-		cashflow.CapitalExpenditures = latest.CapitalExpenditures
-		cashflow.FreeCashFlow = latest.FreeCashFlow
-		cashflow.DividendsPaid = latest.DividendsPaid
+	return CashFlow{}
+}
+
+// extractCashFlowHistory extracts every reported cash flow statement, most recent first
+func (t *Ticker) extractCashFlowHistory(result YahooFinancialResult) []CashFlow {
+	if result.CashflowStatementHistory == nil {
+		return nil
 	}
 
-	return cashflow
+	statements := make([]CashFlow, 0, len(result.CashflowStatementHistory.CashflowStatements))
+	for _, statement := range result.CashflowStatementHistory.CashflowStatements {
+		statements = append(statements, CashFlow{
+			EndDate:             statement.EndDate.AsTime(),
+			OperatingCashFlow:   statement.TotalCashFromOperatingActivities,
+			CapitalExpenditures: statement.CapitalExpenditures,
+			FreeCashFlow:        statement.FreeCashFlow,
+			DividendsPaid:       statement.DividendsPaid,
+		})
+	}
+	return statements
+}
+
+// extractCompanyProfile extracts the company description and officers from the assetProfile module
+func (t *Ticker) extractCompanyProfile(result YahooFinancialResult) CompanyProfile {
+	profile := CompanyProfile{}
+
+	if result.AssetProfile != nil {
+		assetProfile := result.AssetProfile
+		profile.Sector = assetProfile.Sector
+		profile.Industry = assetProfile.Industry
+		profile.Website = assetProfile.Website
+		profile.LongBusinessSummary = assetProfile.LongBusinessSummary
+		profile.FullTimeEmployees = assetProfile.FullTimeEmployees
+		profile.Country = assetProfile.Country
+		profile.City = assetProfile.City
+
+		profile.Officers = make([]CompanyOfficer, 0, len(assetProfile.CompanyOfficers))
+		for _, officer := range assetProfile.CompanyOfficers {
+			profile.Officers = append(profile.Officers, CompanyOfficer{
+				Name:     officer.Name,
+				Title:    officer.Title,
+				Age:      officer.Age,
+				TotalPay: rawValue(officer.TotalPay),
+			})
+		}
+	}
+
+	return profile
 }
 
 // transformHistoricalData converts YahooHistoryResponse into a map of PriceData keyed by date/time
 func transformHistoricalData(data YahooHistoryResponse, interval string) map[string]PriceData {
-	d := make(map[string]PriceData)
 	if len(data.Chart.Result) == 0 {
-		return d
+		return make(map[string]PriceData)
 	}
 
 	result := data.Chart.Result[0]
+	location := exchangeLocation(result.Meta.ExchangeTimezoneName, result.Meta.Gmtoffset)
+	// Size the map up front so long histories don't rehash while growing
+	d := make(map[string]PriceData, len(result.Timestamp))
 	for i, timestamp := range result.Timestamp {
-		t := time.Unix(timestamp, 0)
+		t := time.Unix(timestamp, 0).In(location)
 		var key string
 		if strings.HasSuffix(interval, "d") || strings.HasSuffix(interval, "wk") || strings.HasSuffix(interval, "mo") {
 			key = t.Format("2006-01-02")
@@ -442,8 +293,225 @@ func transformHistoricalData(data YahooHistoryResponse, interval string) map[str
 	return d
 }
 
-// extractDividendInfo extracts dividend information from the API response
-func (t *Ticker) extractDividendInfo(result struct {
+// now is the clock used for date comparisons, replaceable in tests
+var now = time.Now
+
+// latestCloseTime returns the timestamp of the most recent candle that has a close price
+func latestCloseTime(data YahooHistoryResponse) (time.Time, bool) {
+	if len(data.Chart.Result) == 0 || len(data.Chart.Result[0].Indicators.Quote) == 0 {
+		return time.Time{}, false
+	}
+
+	result := data.Chart.Result[0]
+	closes := result.Indicators.Quote[0].Close
+	for i := len(result.Timestamp) - 1; i >= 0; i-- {
+		if i < len(closes) && closes[i] != nil {
+			return time.Unix(result.Timestamp[i], 0), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// dividendEvents returns the dividends found in the chart events, oldest first, dated in location
+func dividendEvents(data YahooHistoryResponse, location *time.Location) []DividendEvent {
+	if len(data.Chart.Result) == 0 {
+		return nil
+	}
+
+	dividends := data.Chart.Result[0].Events.Dividends
+	events := make([]DividendEvent, 0, len(dividends))
+	for _, dividend := range dividends {
+		events = append(events, DividendEvent{Date: time.Unix(dividend.Date, 0).In(location), Amount: dividend.Amount})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Date.Before(events[j].Date) })
+	return events
+}
+
+// capitalGainEvents returns the capital gains distributions found in the chart events, oldest first, dated in location
+func capitalGainEvents(data YahooHistoryResponse, location *time.Location) []CapitalGainEvent {
+	if len(data.Chart.Result) == 0 {
+		return nil
+	}
+
+	gains := data.Chart.Result[0].Events.CapitalGains
+	events := make([]CapitalGainEvent, 0, len(gains))
+	for _, gain := range gains {
+		events = append(events, CapitalGainEvent{Date: time.Unix(gain.Date, 0).In(location), Amount: gain.Amount})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Date.Before(events[j].Date) })
+	return events
+}
+
+// splitEvents returns the stock splits found in the chart events, oldest first, dated in location
+func splitEvents(data YahooHistoryResponse, location *time.Location) []SplitEvent {
+	if len(data.Chart.Result) == 0 {
+		return nil
+	}
+
+	splits := data.Chart.Result[0].Events.Splits
+	events := make([]SplitEvent, 0, len(splits))
+	for _, split := range splits {
+		events = append(events, SplitEvent{
+			Date:        time.Unix(split.Date, 0).In(location),
+			Numerator:   int(math.Round(split.Numerator)),
+			Denominator: int(math.Round(split.Denominator)),
+			SplitRatio:  split.SplitRatio,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Date.Before(events[j].Date) })
+	return events
+}
+
+// tradingDays returns the distinct dates of the candles that have a close, oldest first, as midnight in location
+func tradingDays(data YahooHistoryResponse, location *time.Location) []time.Time {
+	if len(data.Chart.Result) == 0 || len(data.Chart.Result[0].Indicators.Quote) == 0 {
+		return nil
+	}
+
+	result := data.Chart.Result[0]
+	closes := result.Indicators.Quote[0].Close
+	days := make([]time.Time, 0, len(result.Timestamp))
+	for i, timestamp := range result.Timestamp {
+		if i >= len(closes) || closes[i] == nil {
+			continue
+		}
+
+		year, month, day := time.Unix(timestamp, 0).In(location).Date()
+		date := time.Date(year, month, day, 0, 0, 0, 0, location)
+		if len(days) == 0 || date.After(days[len(days)-1]) {
+			days = append(days, date)
+		}
+	}
+	return days
+}
+
+// tradingDaysRange returns the shortest chart range expected to hold n trading days, leaving room for holidays
+func tradingDaysRange(n int) string {
+	switch {
+	case n <= 3:
+		return "5d"
+	case n <= 18:
+		return "1mo"
+	case n <= 58:
+		return "3mo"
+	case n <= 120:
+		return "6mo"
+	case n <= 245:
+		return "1y"
+	case n <= 495:
+		return "2y"
+	case n <= 1245:
+		return "5y"
+	case n <= 2495:
+		return "10y"
+	default:
+		return "max"
+	}
+}
+
+// sameDay reports whether two times fall on the same calendar date in their own locations
+func sameDay(a, b time.Time) bool {
+	aYear, aMonth, aDay := a.Date()
+	bYear, bMonth, bDay := b.Date()
+	return aYear == bYear && aMonth == bMonth && aDay == bDay
+}
+
+// rawValue returns a pointer to the raw value of a PriceValue, or nil when absent
+func rawValue(p *PriceValue) *float64 {
+	if p == nil {
+		return nil
+	}
+	raw := p.Raw
+	return &raw
+}
+
+// extractCalendar converts the calendarEvents module into a Calendar
+func extractCalendar(events yahooCalendarEvents) Calendar {
+	calendar := Calendar{
+		EarningsLow:     rawValue(events.Earnings.EarningsLow),
+		EarningsHigh:    rawValue(events.Earnings.EarningsHigh),
+		EarningsAverage: rawValue(events.Earnings.EarningsAverage),
+		RevenueLow:      rawValue(events.Earnings.RevenueLow),
+		RevenueHigh:     rawValue(events.Earnings.RevenueHigh),
+		RevenueAverage:  rawValue(events.Earnings.RevenueAverage),
+		ExDividendDate:  events.ExDividendDate.AsTime(),
+		DividendDate:    events.DividendDate.AsTime(),
+	}
+
+	// Yahoo lists the announced date, or the window it is expected in; keep the soonest one still ahead
+	today := now().Truncate(24 * time.Hour)
+	for i := range events.Earnings.EarningsDate {
+		date := events.Earnings.EarningsDate[i].AsTime()
+		if date.IsZero() {
+			continue
+		}
+		calendar.EarningsDates = append(calendar.EarningsDates, date)
+		if date.Before(today) {
+			continue
+		}
+		if calendar.NextEarningsDate.IsZero() || date.Before(calendar.NextEarningsDate) {
+			calendar.NextEarningsDate = date
+		}
+	}
+	sort.Slice(calendar.EarningsDates, func(i, j int) bool { return calendar.EarningsDates[i].Before(calendar.EarningsDates[j]) })
+
+	return calendar
+}
+
+// extractEarnings converts the earnings and earningsHistory modules into Earnings
+func extractEarnings(modules yahooEarnings) Earnings {
+	earnings := Earnings{}
+
+	if modules.EarningsHistory != nil {
+		for _, quarter := range modules.EarningsHistory.History {
+			earnings.History = append(earnings.History, EarningsQuarter{
+				Quarter:         quarter.Quarter.AsTime(),
+				Period:          quarter.Period,
+				EPSActual:       quarter.EPSActual,
+				EPSEstimate:     quarter.EPSEstimate,
+				EPSDifference:   quarter.EPSDifference,
+				SurprisePercent: quarter.SurprisePercent,
+			})
+		}
+		sort.SliceStable(earnings.History, func(i, j int) bool { return earnings.History[i].Quarter.Before(earnings.History[j].Quarter) })
+	}
+
+	if modules.Earnings != nil {
+		chart := modules.Earnings.EarningsChart
+		earnings.Quarterly = modules.Earnings.FinancialsChart.Quarterly
+		earnings.CurrentQuarterEstimate = chart.CurrentQuarterEstimate
+
+		// Same rule as the calendar: the soonest date that is not yet past
+		today := now().Truncate(24 * time.Hour)
+		for i := range chart.EarningsDate {
+			date := chart.EarningsDate[i].AsTime()
+			if date.IsZero() || date.Before(today) {
+				continue
+			}
+			if earnings.NextEarningsDate.IsZero() || date.Before(earnings.NextEarningsDate) {
+				earnings.NextEarningsDate = date
+			}
+		}
+	}
+
+	return earnings
+}
+
+// exchangeLocation resolves an exchange timezone by name, falling back to a fixed zone
+// built from the GMT offset (in seconds) when the name is empty or unknown to the tz database
+func exchangeLocation(name string, gmtoffset int) *time.Location {
+	if name != "" {
+		if location, err := time.LoadLocation(name); err == nil {
+			return location
+		}
+	}
+
+	return time.FixedZone(name, gmtoffset)
+}
+
+// yahooDividendResult holds the dividend fields of the summaryDetail, defaultKeyStatistics
+// and cashflowStatementHistory modules
+type yahooDividendResult struct {
 	SummaryDetail *struct {
 		DividendRate             *PriceValue `json:"dividendRate"`
 		DividendYield            *PriceValue `json:"dividendYield"`
@@ -463,7 +531,10 @@ func (t *Ticker) extractDividendInfo(result struct {
 			DividendsPaid *PriceValue `json:"dividendsPaid"`
 		} `json:"cashflowStatements"`
 	} `json:"cashflowStatementHistory"`
-}) DividendInfo {
+}
+
+// extractDividendInfo extracts dividend information from the API response
+func (t *Ticker) extractDividendInfo(result yahooDividendResult) DividendInfo {
 	dividend := DividendInfo{}
 
 	// Prioritize SummaryDetail data